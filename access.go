@@ -51,15 +51,19 @@ type TaskPermissionType struct {
 
 // RecordsCount returns the totalRecordsCount, ownerRecordsCount and error, if applicable
 func (crud *Crud) RecordsCount() (totalRecords int, ownerRecords int, err error) {
-	// totalRecordsCount from the table
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_records FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRecords)
+	// totalRecordsCount from the table, scoped by the app_id filter
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_records")
+	if countErr != nil {
+		return 0, 0, errors.New(fmt.Sprintf("Db query Error[total-records-count]: %v", countErr.Error()))
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRecords)
 	if tRowErr != nil {
 		return 0, 0, errors.New(fmt.Sprintf("Db query Error[total-records-count]: %v", tRowErr.Error()))
 	}
 	// count owner-records
 	sqlScript := fmt.Sprintf("SELECT COUNT(*) AS owner_records FROM %v WHERE created_by = $1", crud.TableName)
-	uRowErr := crud.AppDb.QueryRowx(sqlScript, crud.UserInfo.UserId).Scan(&ownerRecords)
+	uRowErr := readDb.QueryRowx(sqlScript, crud.UserInfo.UserId).Scan(&ownerRecords)
 	if uRowErr != nil {
 		return 0, 0, errors.New(fmt.Sprintf("Db query Error[total-records-count]: %v", uRowErr.Error()))
 	}
@@ -182,7 +186,8 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 	var roleServices []RoleServiceType
 	var rsErr error
 	if len(serviceIds) > 0 {
-		roleServices, rsErr = crud.GetRoleServices(crud.AccessDb, crud.RoleTable, roleId, serviceIds)
+		// the profile role and the assigned (user-roles) roles, including the inherited (ancestors) permissions
+		roleServices, rsErr = crud.effectiveRoleServices(append([]string{roleId}, roleIds...), serviceIds)
 		if rsErr != nil {
 			roleServices = []RoleServiceType{}
 		}
@@ -220,18 +225,33 @@ func (crud *Crud) CheckTaskAccess() mcresponse.ResponseMessage {
 
 // GetRoleServices method process and returns the permission to user / user-group/roleId for the specified service items
 func (crud *Crud) GetRoleServices(accessDb *sqlx.DB, roleTable string, userRoleId string, serviceIds []string) ([]RoleServiceType, error) {
+	return crud.roleServices(accessDb, roleTable, []string{userRoleId}, serviceIds)
+}
+
+// roleServices returns the active role-services (permissions) of the roleIds, for the specified service items
+func (crud *Crud) roleServices(accessDb *sqlx.DB, roleTable string, roleIds []string, serviceIds []string) ([]RoleServiceType, error) {
 	var roleServices []RoleServiceType
-	// where-in-values
-	inValues := ""
-	idLen := len(serviceIds)
-	for idCount, id := range serviceIds {
-		inValues += "'" + id + "'"
-		if idLen > 1 && idCount < idLen-1 {
-			inValues += ", "
+	// where-in placeholders/values
+	var fieldValues []interface{}
+	var servicePlaceholders []string
+	var rolePlaceholders []string
+	for _, id := range serviceIds {
+		fieldValues = append(fieldValues, id)
+		servicePlaceholders = append(servicePlaceholders, fmt.Sprintf("$%v", len(fieldValues)))
+	}
+	for _, id := range roleIds {
+		if id == "" {
+			continue
 		}
+		fieldValues = append(fieldValues, id)
+		rolePlaceholders = append(rolePlaceholders, fmt.Sprintf("$%v", len(fieldValues)))
+	}
+	if len(servicePlaceholders) < 1 || len(rolePlaceholders) < 1 {
+		return roleServices, nil
 	}
-	roleScript := fmt.Sprintf("SELECT role_id, service_id, service_category, can_read, can_create, can_delete, can_update, can_crud from %v WHERE service_id IN (%v) AND role_id=$1 AND is_active=$2", roleTable, inValues)
-	rows, err := accessDb.Queryx(roleScript, userRoleId, true)
+	fieldValues = append(fieldValues, true)
+	roleScript := fmt.Sprintf("SELECT role_id, service_id, service_category, can_read, can_create, can_delete, can_update, can_crud from %v WHERE service_id IN (%v) AND role_id IN (%v) AND is_active=$%v", roleTable, strings.Join(servicePlaceholders, ", "), strings.Join(rolePlaceholders, ", "), len(fieldValues))
+	rows, err := accessDb.Queryx(roleScript, fieldValues...)
 	if err != nil {
 		//errMsg := fmt.Sprintf("Db query Error: %v", err.Error())
 		return roleServices, errors.New(fmt.Sprintf("%v", err.Error()))
@@ -323,10 +343,10 @@ func (crud *Crud) TaskPermissionById(taskType string) mcresponse.ResponseMessage
 	// filter the roleServices by categories ("collection | table" or "record | document")
 	recordIds := crud.RecordIds
 	collTabFunc := func(item RoleServiceType) bool {
-		return item.ServiceCategory == tableId
+		return item.ServiceId == tableId
 	}
 	recordFunc := func(item RoleServiceType) bool {
-		return ArrayStringContains(recordIds, item.ServiceCategory)
+		return ArrayStringContains(recordIds, item.ServiceId)
 	}
 
 	var (
@@ -532,11 +552,8 @@ func (crud *Crud) TaskPermissionByParam(taskType string) mcresponse.ResponseMess
 func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 	// validate current user active status: by token (API) and user/loggedIn-status
 	// get the accessKey information for the user
-	accessScript := fmt.Sprintf("SELECT expire from %v WHERE user_id=$1 AND token=$2 AND login_name=$3", crud.AccessTable)
-	rowAccess := crud.AccessDb.QueryRow(accessScript, crud.UserInfo.UserId, crud.UserInfo.Token, crud.UserInfo.LoginName)
 	// check login-status/expiration
-	var accessExpire int64
-	if aErr := rowAccess.Scan(&accessExpire); aErr != nil {
+	if _, accessExpire, aErr := crud.lookupAccess(crud.UserInfo.UserId, crud.UserInfo.LoginName, crud.UserInfo.Token); aErr != nil {
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("UnAuthorized: please ensure that you are logged-in: %v", aErr.Error()),
 			Value:   nil,
@@ -565,7 +582,7 @@ func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 		})
 	}
 	// get user-role/roleIds and profile/roleId information
-	urScript := fmt.Sprintf("SELECT id from %v WHERE user_id=$1 AND is_active=$2", crud.UserRoleTable)
+	urScript := fmt.Sprintf("SELECT role_id from %v WHERE user_id=$1 AND is_active=$2", crud.UserRoleTable)
 	urRows, urErr := crud.AccessDb.Queryx(urScript, crud.UserInfo.UserId, true)
 
 	//if urErr != nil {
@@ -594,7 +611,7 @@ func (crud *Crud) CheckUserAccess() mcresponse.ResponseMessage {
 	}
 	// user-profile
 	var roleId string
-	upScript := fmt.Sprintf("SELECT id from %v WHERE user_id=$1 AND is_active=$2", crud.ProfileTable)
+	upScript := fmt.Sprintf("SELECT role_id from %v WHERE user_id=$1 AND is_active=$2", crud.ProfileTable)
 	upErr := crud.AccessDb.QueryRowx(upScript, crud.UserInfo.UserId, true).Scan(&roleId)
 	if upErr != nil {
 		roleId = ""
@@ -628,10 +645,7 @@ func (crud *Crud) CheckLoginStatus() mcresponse.ResponseMessage {
 	}
 
 	// check loginName, userId and token validity... from access_keys table
-	var expire int64
-	accessQuery := fmt.Sprintf("SELECT expire from %v WHERE user_id=$1 AND login_name=$2 AND token=$3", crud.AccessTable)
-	aRow := crud.AccessDb.QueryRow(accessQuery, params.UserId, params.LoginName, params.Token)
-	err := aRow.Scan(&expire)
+	storedToken, expire, err := crud.lookupAccess(params.UserId, params.LoginName, params.Token)
 	if err != nil {
 		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Access information for %v not found. Login first, or contact system administrator: %v", params.LoginName, err.Error()),
@@ -641,7 +655,7 @@ func (crud *Crud) CheckLoginStatus() mcresponse.ResponseMessage {
 	if (time.Now().Unix() * 1000) > expire {
 		// Delete the expired access_keys | remove access-info from access_keys table
 		delQuery := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1 AND token=$2", crud.AccessTable)
-		_, _ = crud.AppDb.Exec(delQuery, params.UserId, params.Token)
+		_, _ = crud.AccessDb.Exec(delQuery, params.UserId, storedToken)
 		return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
 			Message: "Access expired: please login to continue",
 			Value:   nil,
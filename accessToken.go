@@ -0,0 +1,84 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: keyed-hash (HMAC-SHA256) access tokens, with the constant-time token comparison
+
+package mcdbcrud
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// hashedTokenPrefix marks the hashed access tokens, the un-prefixed (legacy) tokens are stored in plaintext
+const hashedTokenPrefix = "hmac-sha256:"
+
+// storedAccessToken returns the access table value of the token: the keyed-hash (TokenSecret),
+// or the plaintext token, if the TokenSecret is not specified
+func (crud *Crud) storedAccessToken(token string) string {
+	if crud.TokenSecret == "" {
+		return token
+	}
+	mac := hmac.New(sha256.New, []byte(crud.TokenSecret))
+	mac.Write([]byte(token))
+	return hashedTokenPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// accessTokenMatch compares, in constant time, the token against the stored (hashed or legacy plaintext) token
+func (crud *Crud) accessTokenMatch(storedToken string, token string) bool {
+	if token == "" {
+		return false
+	}
+	if strings.HasPrefix(storedToken, hashedTokenPrefix) {
+		return crud.TokenSecret != "" && hmac.Equal([]byte(storedToken), []byte(crud.storedAccessToken(token)))
+	}
+	// legacy plaintext token, valid until expiry (see PurgeExpired)
+	return subtle.ConstantTimeCompare([]byte(storedToken), []byte(token)) == 1
+}
+
+// lookupAccess returns the stored token and the expiry of the user session (token), by the user-id and login-name,
+// for the access checks. Returns sql.ErrNoRows, if not found
+func (crud *Crud) lookupAccess(userId string, loginName string, token string) (string, int64, error) {
+	accessQuery := fmt.Sprintf("SELECT token, expire from %v WHERE user_id=$1 AND login_name=$2", crud.AccessTable)
+	return crud.matchAccessToken(accessQuery, token, userId, loginName)
+}
+
+// lookupSession returns the stored token and the expiry of the user session (token), by the user-id,
+// for the session refresh and logout. Returns sql.ErrNoRows, if not found
+func (crud *Crud) lookupSession(userId string, token string) (string, int64, error) {
+	accessQuery := fmt.Sprintf("SELECT token, expire from %v WHERE user_id=$1", crud.AccessTable)
+	return crud.matchAccessToken(accessQuery, token, userId)
+}
+
+// matchAccessToken returns the stored token and the expiry of the access query row matching the token
+func (crud *Crud) matchAccessToken(accessQuery string, token string, queryValues ...interface{}) (string, int64, error) {
+	rows, err := crud.AccessDb.Queryx(accessQuery, queryValues...)
+	if err != nil {
+		return "", 0, err
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	for rows.Next() {
+		var (
+			storedToken string
+			expire      int64
+		)
+		if sErr := rows.Scan(&storedToken, &expire); sErr != nil {
+			return "", 0, sErr
+		}
+		if crud.accessTokenMatch(storedToken, token) {
+			return storedToken, expire, nil
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return "", 0, err
+	}
+	return "", 0, sql.ErrNoRows
+}
@@ -0,0 +1,62 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: hashed access tokens test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abbeymart/mctest"
+)
+
+func TestAccessToken(t *testing.T) {
+	sqliteDb := accessTestDb(t, "accessToken.db")
+	userId := accessTestUser(t, sqliteDb, "abbey", false, false).UserId
+	newCrud := func(token string, secret string) *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, TableName: "users",
+			UserInfo: UserInfoType{UserId: userId, LoginName: "abbey", Token: token}},
+			CrudOptionsType{TokenSecret: secret})
+	}
+	const secret = "s3cr3t-key"
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should store the keyed-hash of the login token, and check it transparently:",
+		TestFunc: func() {
+			session, _ := newCrud("", secret).Login().Value.(SessionType)
+			var storedToken string
+			_ = sqliteDb.QueryRowx("SELECT token FROM accesses WHERE user_id=$1", userId).Scan(&storedToken)
+			mctest.AssertEquals(t, strings.HasPrefix(storedToken, hashedTokenPrefix), true, "stored token should be: hashed")
+			mctest.AssertEquals(t, strings.Contains(storedToken, session.Token), false, "stored token should not contain: the plaintext token")
+			mctest.AssertEquals(t, newCrud(session.Token, secret).CheckLoginStatus().Code, "success", "hashed token login-status should be: success")
+			mctest.AssertEquals(t, newCrud(storedToken, secret).CheckLoginStatus().Code, "unAuthorized", "leaked stored token login-status should be: unAuthorized")
+			mctest.AssertEquals(t, newCrud(session.Token, "other-key").CheckLoginStatus().Code, "unAuthorized", "other secret login-status should be: unAuthorized")
+			otherLogin := newCrud(session.Token, secret)
+			otherLogin.UserInfo.LoginName = ""
+			mctest.AssertEquals(t, otherLogin.CheckUserAccess().Code, "unAuthorized", "empty login-name user-access should be: unAuthorized")
+			// the user email, other than the session login-name
+			otherLogin.UserInfo.LoginName = "abbey@mconnect.biz"
+			mctest.AssertEquals(t, otherLogin.CheckLoginStatus().Code, "unAuthorized", "other session login-name login-status should be: unAuthorized")
+			mctest.AssertEquals(t, newCrud(session.Token, secret).Logout().Code, "success", "hashed token logout should be: success")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should accept the legacy plaintext token until expiry, and hash it on refresh:",
+		TestFunc: func() {
+			expire := time.Now().Add(time.Hour).Unix() * 1000
+			_, _ = sqliteDb.Exec("INSERT INTO accesses(user_id, login_name, token, expire) VALUES ($1, 'abbey', 'legacy-token', $2)", userId, expire)
+			_, _ = sqliteDb.Exec("INSERT INTO accesses(user_id, login_name, token, expire) VALUES ($1, 'abbey', 'expired-token', 1)", userId)
+			mctest.AssertEquals(t, newCrud("legacy-token", secret).CheckLoginStatus().Code, "success", "legacy token login-status should be: success")
+			mctest.AssertEquals(t, newCrud("expired-token", secret).CheckLoginStatus().Code, "tokenExpired", "expired legacy token login-status should be: tokenExpired")
+			mctest.AssertEquals(t, newCrud("legacy-token", secret).Refresh(false).Code, "success", "legacy token refresh should be: success")
+			var storedToken string
+			_ = sqliteDb.QueryRowx("SELECT token FROM accesses WHERE user_id=$1", userId).Scan(&storedToken)
+			mctest.AssertEquals(t, strings.HasPrefix(storedToken, hashedTokenPrefix), true, "refreshed legacy token should be: hashed")
+			mctest.AssertEquals(t, newCrud("legacy-token", secret).CheckLoginStatus().Code, "success", "refreshed legacy token login-status should be: success")
+		},
+	})
+
+	mctest.PostTestResult()
+}
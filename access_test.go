@@ -0,0 +1,45 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: access tables test fixture (users, sessions, roles and items), by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// accessTestDb opens the sqlite3 test db, closed on the test cleanup, with the access tables (BootstrapTables)
+// and the items table (record r1, created by other)
+func accessTestDb(t *testing.T, dbName string) *sqlx.DB {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), dbName))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	t.Cleanup(func() {
+		_ = sqliteDb.Close()
+	})
+	if err := BootstrapTables(sqliteDb, "sqlite3", CrudOptionsType{}); err != nil {
+		t.Fatalf("error creating the access tables: %v", err)
+	}
+	_, _ = sqliteDb.Exec("CREATE TABLE items (id TEXT PRIMARY KEY, name TEXT, created_by TEXT)")
+	_, _ = sqliteDb.Exec("INSERT INTO items(id, name, created_by) VALUES ('r1', 'abc', 'other')")
+	return sqliteDb
+}
+
+// accessTestUser creates the user (username, and username@mconnect.biz email), and the login session, if login
+func accessTestUser(t *testing.T, sqliteDb *sqlx.DB, username string, isAdmin bool, login bool) UserInfoType {
+	var userId string
+	if err := sqliteDb.QueryRowx("INSERT INTO users(username, email, password, is_admin) VALUES ($1, $2, 'x', $3) RETURNING id",
+		username, username+"@mconnect.biz", isAdmin).Scan(&userId); err != nil {
+		t.Fatalf("error creating the user(%v): %v", username, err)
+	}
+	userInfo := UserInfoType{UserId: userId, LoginName: username}
+	if login {
+		session, _ := NewCrud(CrudParamsType{AppDb: sqliteDb, UserInfo: userInfo}, CrudOptionsType{}).Login().Value.(SessionType)
+		userInfo.Token = session.Token
+	}
+	return userInfo
+}
@@ -0,0 +1,144 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: row-level app_id filtering (shared-table multi-tenancy), by the permitted app-ids
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/abbeymart/mcresponse"
+)
+
+var queryFieldRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+
+// appIdScope returns the permitted app-ids of the crud-instance, and whether the row-level app_id filter applies:
+// the PermittedAppIds (AppIdFilter), and/or the shared-table tenant-id (Tenancy)
+func (crud *Crud) appIdScope() ([]string, bool) {
+	sharedTable := crud.Tenancy != nil && crud.Tenancy.Strategy() == TenantSharedTable
+	if !crud.AppIdFilter && !sharedTable {
+		return nil, false
+	}
+	if !sharedTable {
+		return crud.PermittedAppIds, true
+	}
+	if crud.AppIdFilter && !ArrayStringContains(crud.PermittedAppIds, crud.tenant.TenantId) {
+		return []string{}, true
+	}
+	return []string{crud.tenant.TenantId}, true
+}
+
+// appIdFiltered returns whether the crud-instance records are filtered by the app_id scope
+func (crud *Crud) appIdFiltered() bool {
+	_, ok := crud.appIdScope()
+	return ok
+}
+
+// appIdCheck applies the row-level app_id filter to the crud-task: the records appId are validated (create/update)
+// or stamped (create, single permitted app-id), the record-ids must belong to the permitted app-ids,
+// and the query-params are scoped by the permitted app-ids, replacing any specified app_id condition
+func (crud *Crud) appIdCheck(taskType string, recs ActionParamsType, recordIds []string) (mcresponse.ResponseMessage, bool) {
+	appIds, ok := crud.appIdScope()
+	if !ok {
+		return mcresponse.ResponseMessage{}, true
+	}
+	if len(appIds) < 1 {
+		return appIdErrMessage("no permitted appIds"), false
+	}
+	for _, rec := range recs {
+		recAppId := ""
+		for key, value := range rec {
+			if whereFieldName(key) == "app_id" {
+				if recAppId = fmt.Sprintf("%v", value); !ArrayStringContains(appIds, recAppId) {
+					return appIdErrMessage(fmt.Sprintf("record appId(%v) is not permitted", recAppId)), false
+				}
+			}
+		}
+		if recAppId == "" && taskType == CreateTask {
+			if len(appIds) > 1 {
+				return appIdErrMessage("record appId is required, for the multiple permitted appIds"), false
+			}
+			rec["appId"] = appIds[0]
+		}
+	}
+	// query-params field-names, to prevent the where-condition injection
+	queryParams := QueryParamType{}
+	for key, value := range crud.QueryParams {
+		if !queryFieldRegex.MatchString(key) {
+			return appIdErrMessage(fmt.Sprintf("invalid query-param field-name(%v)", key)), false
+		}
+		if whereFieldName(key) != "app_id" {
+			queryParams[key] = value
+		}
+	}
+	if len(recordIds) > 0 {
+		if err := crud.checkAppIdRecordIds(appIds, recordIds); err != nil {
+			return appIdErrMessage(err.Error()), false
+		}
+	}
+	queryParams["appId"] = appIds
+	crud.QueryParams = queryParams
+	return mcresponse.ResponseMessage{}, true
+}
+
+// recordsCountQuery returns the total records count-query (countAlias) and field-values: scoped by the row-level
+// app_id filter, with the query-params where-condition and the permitted app-ids (as the by-param select-query)
+func (crud *Crud) recordsCountQuery(countAlias string) (string, []interface{}, error) {
+	countQuery := fmt.Sprintf("SELECT COUNT(*) AS %v FROM %v", countAlias, crud.TableName)
+	appIds, ok := crud.appIdScope()
+	if !ok {
+		return countQuery, nil, nil
+	}
+	queryParams := QueryParamType{}
+	for key, value := range crud.QueryParams {
+		if whereFieldName(key) != "app_id" {
+			queryParams[key] = value
+		}
+	}
+	queryParams["appId"] = appIds
+	whereRes := ComputeWhereQuery(queryParams, 1)
+	if !whereRes.Ok {
+		return "", nil, errors.New(whereRes.Message)
+	}
+	return fmt.Sprintf("%v %v", countQuery, whereRes.WhereQueryObject.WhereQuery), whereRes.WhereQueryObject.FieldValues, nil
+}
+
+// checkAppIdRecordIds verifies that all the record-ids belong to the permitted app-ids, from the primary
+func (crud *Crud) checkAppIdRecordIds(appIds []string, recordIds []string) error {
+	uniqueIds := map[string]bool{}
+	var idPlaceholders []string
+	var appIdPlaceholders []string
+	var fieldValues []interface{}
+	for _, id := range recordIds {
+		if uniqueIds[id] {
+			continue
+		}
+		uniqueIds[id] = true
+		fieldValues = append(fieldValues, id)
+		idPlaceholders = append(idPlaceholders, fmt.Sprintf("$%v", len(fieldValues)))
+	}
+	for _, appId := range appIds {
+		fieldValues = append(fieldValues, appId)
+		appIdPlaceholders = append(appIdPlaceholders, fmt.Sprintf("$%v", len(fieldValues)))
+	}
+	countQuery := fmt.Sprintf("SELECT COUNT(*) AS app_records FROM %v WHERE id IN (%v) AND app_id IN (%v)",
+		crud.TableName, strings.Join(idPlaceholders, ", "), strings.Join(appIdPlaceholders, ", "))
+	var appRecords int
+	if err := crud.AppDb.QueryRowx(countQuery, fieldValues...).Scan(&appRecords); err != nil {
+		return errors.New(fmt.Sprintf("Db query Error[app-records-count]: %v", err.Error()))
+	}
+	if appRecords != len(uniqueIds) {
+		return errors.New("record-ids not found for the permitted appIds")
+	}
+	return nil
+}
+
+func appIdErrMessage(errMsg string) mcresponse.ResponseMessage {
+	return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("App access error: %v", errMsg),
+		Value:   nil,
+	})
+}
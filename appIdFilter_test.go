@@ -0,0 +1,131 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: row-level app_id filter test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestAppIdFilter(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "appIdFilter.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const appTable = "app_items"
+	_ = CreateTable(sqliteDb, tenantTestModel{}, appTable, "sqlite3")
+	newCrud := func(appIds ...string) *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: tenantTestModel{}, ModelPointer: &tenantTestModel{},
+			TableName: appTable, PermittedAppIds: appIds}, CrudOptionsType{AppIdFilter: true})
+	}
+	getRecords := func(crud *Crud) []map[string]interface{} {
+		value, _ := crud.GetAll().Value.(GetResultType)
+		return value.Records
+	}
+	var a3Id string
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should stamp or validate the create records appId:",
+		TestFunc: func() {
+			res := newCrud("a1").Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "stamped create response-code should be: success")
+			res = newCrud("a1", "a2").Create(ActionParamsType{{"name": "def", "appId": "a2"}})
+			mctest.AssertEquals(t, res.Code, "success", "permitted appId create response-code should be: success")
+			res = newCrud("a1", "a2").Create(ActionParamsType{{"name": "ghi"}})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "unspecified appId (multiple permitted) response-code should be: unAuthorized")
+			res = newCrud("a1").Create(ActionParamsType{{"name": "jkl", "appId": "a3"}})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "not-permitted appId response-code should be: unAuthorized")
+			res = newCrud("a3").Create(ActionParamsType{{"name": "mno"}})
+			value, _ := res.Value.(CrudResultType)
+			a3Id = value.RecordIds[0]
+			res = newCrud().Create(ActionParamsType{{"name": "pqr"}})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "no permitted appIds response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should filter the reads by the permitted appIds, without bypass by the query-params:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, len(getRecords(newCrud("a1"))), 1, "a1 records should be: 1")
+			mctest.AssertEquals(t, len(getRecords(newCrud("a1", "a2"))), 2, "a1 and a2 records should be: 2")
+			crud := newCrud("a1")
+			crud.QueryParams = QueryParamType{"app_id": "a3"}
+			value, _ := crud.GetByParam().Value.(GetResultType)
+			mctest.AssertEquals(t, len(value.Records), 1, "app_id query-param records should be: 1 (a1)")
+			mctest.AssertEquals(t, value.Records[0]["appId"], "a1", "app_id query-param record appId should be: a1")
+			crud = newCrud("a1")
+			crud.QueryParams = QueryParamType{"name=name OR app_id": "a3"}
+			mctest.AssertEquals(t, crud.GetByParam().Code, "unAuthorized", "field-name injection response-code should be: unAuthorized")
+			crud = newCrud("a1")
+			crud.QueryParams = QueryParamType{"name": []string{"x') OR ('1'='1"}}
+			value, _ = crud.GetByParam().Value.(GetResultType)
+			mctest.AssertEquals(t, len(value.Records), 0, "field-value injection records should be: 0")
+			mctest.AssertEquals(t, newCrud("a1").GetById(a3Id).Code, "unAuthorized", "not-permitted get-by-id response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should count the total records by the permitted appIds:",
+		TestFunc: func() {
+			value, _ := newCrud("a1").GetAll().Value.(GetResultType)
+			mctest.AssertEquals(t, value.Stats.TotalRecordsCount, 1, "a1 total records count should be: 1")
+			value, _ = newCrud("a1", "a2").GetById(a3Id).Value.(GetResultType)
+			mctest.AssertEquals(t, value.Stats.TotalRecordsCount, 0, "not-permitted get-by-id total records count should be: 0")
+			crud := newCrud("a1", "a2")
+			crud.QueryParams = QueryParamType{"app_id": "a3"}
+			value, _ = crud.GetByParam().Value.(GetResultType)
+			mctest.AssertEquals(t, value.Stats.TotalRecordsCount, 2, "app_id query-param total records count should be: 2 (a1, a2)")
+			_, _ = sqliteDb.Exec("CREATE TABLE app_owned_items (id TEXT PRIMARY KEY, app_id TEXT, created_by TEXT)")
+			_, _ = sqliteDb.Exec("INSERT INTO app_owned_items(id, app_id, created_by) VALUES ('r1', 'a1', 'u1'), ('r2', 'a2', 'u1'), ('r3', 'a3', 'u1')")
+			crud = newCrud("a1", "a2")
+			crud.TableName = "app_owned_items"
+			totalRecords, _, err := crud.RecordsCount()
+			mctest.AssertEquals(t, err, nil, "records count error should be: nil")
+			mctest.AssertEquals(t, totalRecords, 2, "a1 and a2 records count should be: 2")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should refuse the records appId, set by the before-hooks:",
+		TestFunc: func() {
+			moveAppId := func(params *HookParamsType) error {
+				for _, rec := range params.ActionParams {
+					rec["appId"] = "a3"
+				}
+				return nil
+			}
+			hookCrud := func() *Crud {
+				return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: tenantTestModel{}, ModelPointer: &tenantTestModel{},
+					TableName: appTable, PermittedAppIds: []string{"a1"}}, CrudOptionsType{AppIdFilter: true,
+					Hooks: CrudHooksType{BeforeCreate: []HookFunc{moveAppId}, BeforeUpdate: []HookFunc{moveAppId}}})
+			}
+			res := hookCrud().Create(ActionParamsType{{"name": "stu"}})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "before-hook appId create response-code should be: unAuthorized")
+			crud := hookCrud()
+			crud.QueryParams = QueryParamType{"name": "abc"}
+			res = crud.UpdateByParam(ActionParamType{"name": "abc"})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "before-hook appId update response-code should be: unAuthorized")
+			mctest.AssertEquals(t, len(getRecords(newCrud("a3"))), 1, "a3 records should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should filter the updates and deletes by the permitted appIds:",
+		TestFunc: func() {
+			res := newCrud("a1").UpdateById(ActionParamType{"name": "xyz"}, a3Id)
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "not-permitted update response-code should be: unAuthorized")
+			res = newCrud("a3").UpdateById(ActionParamType{"name": "xyz", "appId": "a1"}, a3Id)
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "appId move update response-code should be: unAuthorized")
+			res = newCrud("a3").UpdateById(ActionParamType{"name": "xyz"}, a3Id)
+			mctest.AssertEquals(t, res.Code, "success", "permitted update response-code should be: success")
+			res = newCrud("a1").DeleteById(a3Id)
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "not-permitted delete response-code should be: unAuthorized")
+			_ = newCrud("a1").DeleteAll()
+			mctest.AssertEquals(t, len(getRecords(newCrud("a1", "a2", "a3"))), 2, "remaining records should be: 2 (a2, a3)")
+		},
+	})
+
+	mctest.PostTestResult()
+}
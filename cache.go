@@ -0,0 +1,341 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: pluggable query-results cache (mccache adapter and size-bounded LRU), tagged by table
+
+package mcdbcrud
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/abbeymart/mccache"
+)
+
+// Cache is the query-results cache backend. Entries are tagged (by table-name), for the write invalidation
+type Cache interface {
+	Get(key string) (interface{}, bool)
+	Set(key string, value interface{}, ttl time.Duration, tags ...string) error
+	Delete(key string) error
+	DeleteByTag(tag string) error
+}
+
+// DefaultCache is the process-wide cache, used when CrudOptionsType.Cache is not specified
+var DefaultCache Cache = NewMcCache()
+
+// McCache is the Cache adapter for the mccache (in-memory) package. The entry tag is the mccache hash
+type McCache struct {
+	keyTags map[string]mcCacheKeyType // key => tag(hash) and expiry
+	pruneAt int                       // prunes the expired keyTags, once the keyTags size reaches pruneAt
+	mutex   sync.RWMutex
+}
+
+// mcCacheKeyType is the tagged cache-key tag(hash) and expiry (zero: by mccache default)
+type mcCacheKeyType struct {
+	tag      string
+	expireAt time.Time
+}
+
+// mcCachePruneSize is the minimum keyTags size, for the expired keyTags pruning
+const mcCachePruneSize = 1024
+
+// NewMcCache constructor returns a new mccache adapter instance
+func NewMcCache() *McCache {
+	return &McCache{keyTags: map[string]mcCacheKeyType{}, pruneAt: mcCachePruneSize}
+}
+
+func (c *McCache) Get(key string) (interface{}, bool) {
+	c.mutex.RLock()
+	keyTag, tagged := c.keyTags[key]
+	c.mutex.RUnlock()
+	var cacheRes mccache.CacheResponseType
+	if tagged {
+		cacheRes = mccache.GetHashCache(key, keyTag.tag)
+	} else {
+		cacheRes = mccache.GetCache(key)
+	}
+	if !cacheRes.Ok || cacheRes.Value == nil {
+		if tagged {
+			// expired or evicted entry: remove the key tag, unless set again meanwhile
+			c.mutex.Lock()
+			if c.keyTags[key] == keyTag {
+				delete(c.keyTags, key)
+			}
+			c.mutex.Unlock()
+		}
+		return nil, false
+	}
+	return cacheRes.Value, true
+}
+
+// Set caches the value by key, under the first tag (mccache hash), if specified
+func (c *McCache) Set(key string, value interface{}, ttl time.Duration, tags ...string) error {
+	expire := int64(ttl / time.Second)
+	if len(tags) < 1 {
+		_ = mccache.SetCache(key, value, expire)
+		return nil
+	}
+	keyTag := mcCacheKeyType{tag: tags[0]}
+	if ttl > 0 {
+		keyTag.expireAt = time.Now().Add(ttl)
+	}
+	c.mutex.Lock()
+	c.keyTags[key] = keyTag
+	if len(c.keyTags) >= c.pruneAt {
+		c.pruneExpired()
+	}
+	c.mutex.Unlock()
+	_ = mccache.SetHashCache(key, tags[0], value, expire)
+	return nil
+}
+
+// pruneExpired removes the expired keyTags, and doubles the pruneAt size of the remaining (live) keyTags.
+// Call with the write-lock
+func (c *McCache) pruneExpired() {
+	now := time.Now()
+	for key, keyTag := range c.keyTags {
+		if !keyTag.expireAt.IsZero() && now.After(keyTag.expireAt) {
+			delete(c.keyTags, key)
+		}
+	}
+	c.pruneAt = 2 * len(c.keyTags)
+	if c.pruneAt < mcCachePruneSize {
+		c.pruneAt = mcCachePruneSize
+	}
+}
+
+func (c *McCache) Delete(key string) error {
+	c.mutex.Lock()
+	keyTag, tagged := c.keyTags[key]
+	delete(c.keyTags, key)
+	c.mutex.Unlock()
+	if tagged {
+		_ = mccache.DeleteHashCache(key, keyTag.tag, "key")
+		return nil
+	}
+	_ = mccache.DeleteCache(key)
+	return nil
+}
+
+func (c *McCache) DeleteByTag(tag string) error {
+	c.mutex.Lock()
+	for key, keyTag := range c.keyTags {
+		if keyTag.tag == tag {
+			delete(c.keyTags, key)
+		}
+	}
+	c.mutex.Unlock()
+	_ = mccache.DeleteHashCache("", tag, "hash")
+	return nil
+}
+
+// lruEntry is the LRU cache list-element value
+type lruEntry struct {
+	key      string
+	value    interface{}
+	expireAt time.Time // zero: no expiry
+	tags     []string
+}
+
+// LRUCache is the size-bounded, least-recently-used Cache. Expired entries are removed on access
+type LRUCache struct {
+	maxEntries int
+	entries    *list.List
+	items      map[string]*list.Element
+	tagKeys    map[string]map[string]struct{}
+	mutex      sync.Mutex
+}
+
+// NewLRUCache constructor returns a new LRU cache instance, bounded by maxEntries (default: 1000)
+func NewLRUCache(maxEntries int) *LRUCache {
+	if maxEntries <= 0 {
+		maxEntries = 1000
+	}
+	return &LRUCache{
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		items:      map[string]*list.Element{},
+		tagKeys:    map[string]map[string]struct{}{},
+	}
+}
+
+func (c *LRUCache) Get(key string) (interface{}, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !entry.expireAt.IsZero() && time.Now().After(entry.expireAt) {
+		c.removeElement(element)
+		return nil, false
+	}
+	c.entries.MoveToFront(element)
+	return entry.value, true
+}
+
+func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration, tags ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.items[key]; ok {
+		c.removeElement(element)
+	}
+	entry := &lruEntry{key: key, value: value, tags: tags}
+	if ttl > 0 {
+		entry.expireAt = time.Now().Add(ttl)
+	}
+	c.items[key] = c.entries.PushFront(entry)
+	for _, tag := range tags {
+		if c.tagKeys[tag] == nil {
+			c.tagKeys[tag] = map[string]struct{}{}
+		}
+		c.tagKeys[tag][key] = struct{}{}
+	}
+	// evict the least-recently-used entries
+	for c.entries.Len() > c.maxEntries {
+		c.removeElement(c.entries.Back())
+	}
+	return nil
+}
+
+func (c *LRUCache) Delete(key string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.items[key]; ok {
+		c.removeElement(element)
+	}
+	return nil
+}
+
+func (c *LRUCache) DeleteByTag(tag string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for key := range c.tagKeys[tag] {
+		if element, ok := c.items[key]; ok {
+			c.removeElement(element)
+		}
+	}
+	delete(c.tagKeys, tag)
+	return nil
+}
+
+// Len returns the cached entries count
+func (c *LRUCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.entries.Len()
+}
+
+// removeElement removes the list-element, and its key and tag references (lock held)
+func (c *LRUCache) removeElement(element *list.Element) {
+	entry := element.Value.(*lruEntry)
+	c.entries.Remove(element)
+	delete(c.items, entry.key)
+	for _, tag := range entry.tags {
+		if keys, ok := c.tagKeys[tag]; ok {
+			delete(keys, entry.key)
+			if len(keys) < 1 {
+				delete(c.tagKeys, tag)
+			}
+		}
+	}
+}
+
+// cache read-types, for the cache-key computation
+const (
+	CacheReadById    = "id"
+	CacheReadByIds   = "ids"
+	CacheReadByParam = "param"
+)
+
+// CacheKeyParamsType is the canonical query description, hashed for the query-results cache-key
+type CacheKeyParamsType struct {
+	TableName     string           `json:"table"`
+	ReadType      string           `json:"readType"`
+	RecordIds     []string         `json:"recordIds"`
+	QueryParams   QueryParamType   `json:"queryParams"`
+	ProjectParams ProjectParamType `json:"projectParams"`
+	SortParams    SortParamType    `json:"sortParams"`
+	Skip          int              `json:"skip"`
+	Limit         int              `json:"limit"`
+	Scope         string           `json:"scope"`              // user-scope, for the access-checked reads
+	TenantId      string           `json:"tenantId,omitempty"` // tenant-scope, for the multi-tenancy reads
+}
+
+// ComputeCacheKey returns the deterministic cache-key (<table>:<sha256-hex>) of the query description.
+// Map keys are encoded in sorted order, and the record-ids are sorted, for the canonical form
+func ComputeCacheKey(params CacheKeyParamsType) string {
+	if len(params.RecordIds) > 0 {
+		recordIds := append([]string{}, params.RecordIds...)
+		sort.Strings(recordIds)
+		params.RecordIds = recordIds
+	}
+	keyValue, err := json.Marshal(params)
+	if err != nil {
+		keyValue = []byte(fmt.Sprintf("%#v", params))
+	}
+	keyHash := sha256.Sum256(keyValue)
+	return fmt.Sprintf("%v:%v", params.TableName, hex.EncodeToString(keyHash[:]))
+}
+
+// readCacheKey returns the cache-key of the read-query: the CacheKey override, if specified,
+// or computed by CacheKeyFunc or ComputeCacheKey
+func (crud *Crud) readCacheKey(readType string, recordIds []string) string {
+	if crud.CacheKey != "" {
+		return crud.CacheKey
+	}
+	keyParams := CacheKeyParamsType{
+		TableName:     crud.TableName,
+		ReadType:      readType,
+		RecordIds:     recordIds,
+		QueryParams:   crud.QueryParams,
+		ProjectParams: crud.ProjectParams,
+		SortParams:    crud.SortParams,
+		Skip:          crud.Skip,
+		Limit:         crud.Limit,
+	}
+	if crud.CheckAccess {
+		keyParams.Scope = crud.UserInfo.UserId
+	}
+	if crud.Tenancy != nil {
+		keyParams.TenantId = crud.tenant.TenantId
+	}
+	if crud.CacheKeyFunc != nil {
+		return crud.CacheKeyFunc(keyParams)
+	}
+	return ComputeCacheKey(keyParams)
+}
+
+// cacheEntryType is the cached query-result, with the freshness deadline (stale-while-revalidate)
+type cacheEntryType struct {
+	Result     GetResultType
+	FreshUntil time.Time
+}
+
+// getCache returns the cached query-result, by the cache-key, and whether the result is fresh (not stale)
+func (crud *Crud) getCache(cacheKey string) (GetResultType, bool, bool) {
+	cacheValue, ok := crud.Cache.Get(cacheKey)
+	if !ok {
+		return GetResultType{}, false, false
+	}
+	entry, ok := cacheValue.(cacheEntryType)
+	if !ok || len(entry.Result.Records) < 1 {
+		return GetResultType{}, false, false
+	}
+	return entry.Result, time.Now().Before(entry.FreshUntil), true
+}
+
+// setCache caches the query-result, by the cache-key, tagged by the table-name. The entry is kept
+// for CacheStaleExpire (secs) after CacheExpire, for the stale-while-revalidate reads
+func (crud *Crud) setCache(cacheKey string, getResult GetResultType) {
+	freshTtl := time.Duration(crud.CacheExpire) * time.Second
+	staleTtl := time.Duration(crud.CacheStaleExpire) * time.Second
+	entry := cacheEntryType{Result: getResult, FreshUntil: time.Now().Add(freshTtl)}
+	_ = crud.Cache.Set(cacheKey, entry, freshTtl+staleTtl, crud.TableName)
+}
@@ -0,0 +1,119 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: cache stampede protection: single-flight reads, stale-while-revalidate and cache metrics
+
+package mcdbcrud
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/abbeymart/mcresponse"
+)
+
+// CacheMetricsType is the query-results cache metrics (counts)
+type CacheMetricsType struct {
+	Hits      int64 `json:"hits"`      // fresh cache-results
+	StaleHits int64 `json:"staleHits"` // stale cache-results, served while refreshing
+	Misses    int64 `json:"misses"`    // db reads
+	Coalesced int64 `json:"coalesced"` // reads sharing an identical in-flight db read
+}
+
+var cacheMetrics CacheMetricsType
+
+// GetCacheMetrics returns the cache metrics, since start or the last reset
+func GetCacheMetrics() CacheMetricsType {
+	return CacheMetricsType{
+		Hits:      atomic.LoadInt64(&cacheMetrics.Hits),
+		StaleHits: atomic.LoadInt64(&cacheMetrics.StaleHits),
+		Misses:    atomic.LoadInt64(&cacheMetrics.Misses),
+		Coalesced: atomic.LoadInt64(&cacheMetrics.Coalesced),
+	}
+}
+
+// ResetCacheMetrics resets the cache metrics counts
+func ResetCacheMetrics() {
+	atomic.StoreInt64(&cacheMetrics.Hits, 0)
+	atomic.StoreInt64(&cacheMetrics.StaleHits, 0)
+	atomic.StoreInt64(&cacheMetrics.Misses, 0)
+	atomic.StoreInt64(&cacheMetrics.Coalesced, 0)
+}
+
+// flightCall is the in-flight read, shared by the identical (same cache-key) reads
+type flightCall struct {
+	wg  sync.WaitGroup
+	res mcresponse.ResponseMessage
+}
+
+// flightGroup coalesces the identical in-flight reads, by cache-key
+type flightGroup struct {
+	calls map[string]*flightCall
+	mutex sync.Mutex
+}
+
+var readFlights = &flightGroup{calls: map[string]*flightCall{}}
+
+// do runs the read-function once for the concurrent calls of the key, and returns the shared response
+func (g *flightGroup) do(key string, readFunc func() mcresponse.ResponseMessage) (mcresponse.ResponseMessage, bool) {
+	g.mutex.Lock()
+	if call, ok := g.calls[key]; ok {
+		g.mutex.Unlock()
+		call.wg.Wait()
+		return call.res, true
+	}
+	call := &flightCall{}
+	call.wg.Add(1)
+	g.calls[key] = call
+	g.mutex.Unlock()
+
+	defer func() {
+		g.mutex.Lock()
+		delete(g.calls, key)
+		g.mutex.Unlock()
+		call.wg.Done()
+	}()
+	call.res = readFunc()
+	return call.res, false
+}
+
+// cachedRead returns the cached read-result (fresh, or stale while one goroutine refreshes it, if CacheStaleExpire),
+// or the db read-result, coalescing the identical in-flight reads. The readFunc performs the db read (and sets the
+// cache), by the crud copy with CacheResult disabled
+func (crud *Crud) cachedRead(cacheKey string, readFunc func(readCrud *Crud) mcresponse.ResponseMessage) mcresponse.ResponseMessage {
+	dbRead := func() mcresponse.ResponseMessage {
+		readCrud := *crud
+		readCrud.CacheResult = false
+		return readFunc(&readCrud)
+	}
+	if val, fresh, ok := crud.getCache(cacheKey); ok {
+		if fresh {
+			atomic.AddInt64(&cacheMetrics.Hits, 1)
+			return cacheResMessage(val)
+		}
+		if crud.CacheStaleExpire > 0 {
+			atomic.AddInt64(&cacheMetrics.StaleHits, 1)
+			// refresh in the background, once per cache-key
+			go readFlights.do(cacheKey, func() mcresponse.ResponseMessage {
+				atomic.AddInt64(&cacheMetrics.Misses, 1)
+				return dbRead()
+			})
+			return cacheResMessage(val)
+		}
+	}
+	res, shared := readFlights.do(cacheKey, func() mcresponse.ResponseMessage {
+		atomic.AddInt64(&cacheMetrics.Misses, 1)
+		return dbRead()
+	})
+	if shared {
+		atomic.AddInt64(&cacheMetrics.Coalesced, 1)
+	}
+	return res
+}
+
+// cacheResMessage returns the success response of the cached read-result
+func cacheResMessage(val GetResultType) mcresponse.ResponseMessage {
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "records successfully retrieved from the cache",
+		Value:   val,
+	})
+}
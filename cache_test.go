@@ -0,0 +1,212 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: cache backend test cases (LRU, and crud read/write paths by sqlite3 db)
+
+package mcdbcrud
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/abbeymart/mccache"
+	"github.com/abbeymart/mcresponse"
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestLRUCache(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should evict the least-recently-used entries, by the size-bound:",
+		TestFunc: func() {
+			cache := NewLRUCache(2)
+			_ = cache.Set("a", 1, 0, "users")
+			_ = cache.Set("b", 2, 0, "users")
+			_, _ = cache.Get("a")
+			_ = cache.Set("c", 3, 0, "roles")
+			_, bOk := cache.Get("b")
+			aValue, aOk := cache.Get("a")
+			mctest.AssertEquals(t, bOk, false, "evicted entry(b) should be: not found")
+			mctest.AssertEquals(t, aOk, true, "recently-used entry(a) should be: found")
+			mctest.AssertEquals(t, aValue, 1, "entry(a) value should be: 1")
+			mctest.AssertEquals(t, cache.Len(), 2, "cache length should be: 2")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should delete the entries by tag, and expire by ttl:",
+		TestFunc: func() {
+			cache := NewLRUCache(10)
+			_ = cache.Set("a", 1, 0, "users")
+			_ = cache.Set("b", 2, 0, "roles")
+			_ = cache.Set("c", 3, time.Nanosecond, "roles")
+			_ = cache.DeleteByTag("users")
+			_, aOk := cache.Get("a")
+			_, bOk := cache.Get("b")
+			time.Sleep(time.Millisecond)
+			_, cOk := cache.Get("c")
+			mctest.AssertEquals(t, aOk, false, "tagged entry(a) should be: deleted")
+			mctest.AssertEquals(t, bOk, true, "other-tag entry(b) should be: found")
+			mctest.AssertEquals(t, cOk, false, "expired entry(c) should be: not found")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestMcCache(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should prune the expired key tags, once the key tags size reaches the prune size:",
+		TestFunc: func() {
+			cache := NewMcCache()
+			for i := 0; i < mcCachePruneSize-1; i++ {
+				_ = cache.Set(fmt.Sprintf("key-%v", i), i, time.Millisecond, "users")
+			}
+			time.Sleep(2 * time.Millisecond)
+			_ = cache.Set("live", 1, time.Minute, "users")
+			mctest.AssertEquals(t, len(cache.keyTags), 1, "key tags size should be: 1")
+			mctest.AssertEquals(t, cache.pruneAt, mcCachePruneSize, "prune size should be: mcCachePruneSize")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should remove the key tag, on the missed (expired or evicted) get:",
+		TestFunc: func() {
+			cache := NewMcCache()
+			_ = cache.Set("a", 1, time.Minute, "users")
+			_ = mccache.DeleteHashCache("a", "users", "key")
+			_, aOk := cache.Get("a")
+			mctest.AssertEquals(t, aOk, false, "evicted entry(a) should be: not found")
+			mctest.AssertEquals(t, len(cache.keyTags), 0, "key tags size should be: 0")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestCrudCache(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "cache.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const cacheTable = "cache_items"
+	_ = CreateTable(sqliteDb, hookTestModel{}, cacheTable, "sqlite3")
+	cache := NewLRUCache(10)
+	_ = cache.Set("other-key", "other", 0, "other_items")
+	newCrud := func() *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: cacheTable},
+			CrudOptionsType{Cache: cache, CacheResult: true})
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should cache the read result, and invalidate only the written table entries:",
+		TestFunc: func() {
+			createRes := newCrud().Create(ActionParamsType{{"name": "abc"}})
+			value, _ := createRes.Value.(CrudResultType)
+			res := newCrud().GetById(value.RecordIds[0])
+			mctest.AssertEquals(t, res.Code, "success", "get response-code should be: success")
+			mctest.AssertEquals(t, cache.Len(), 2, "cache length should be: 2")
+			res = newCrud().GetById(value.RecordIds[0])
+			mctest.AssertEquals(t, res.Message, "records successfully retrieved from the cache", "get message should be: from the cache")
+			_ = newCrud().Create(ActionParamsType{{"name": "xyz"}})
+			mctest.AssertEquals(t, cache.Len(), 1, "cache length should be: 1")
+			_, ok := cache.Get("other-key")
+			mctest.AssertEquals(t, ok, true, "other table entry should be: found")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestComputeCacheKey(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the same cache-key, for the equivalent queries:",
+		TestFunc: func() {
+			key1 := ComputeCacheKey(CacheKeyParamsType{TableName: "users", ReadType: CacheReadByIds, RecordIds: []string{"b", "a"},
+				QueryParams: QueryParamType{"name": "abc", "age": 20}, SortParams: SortParamType{"name": 1}, Limit: 10})
+			key2 := ComputeCacheKey(CacheKeyParamsType{TableName: "users", ReadType: CacheReadByIds, RecordIds: []string{"a", "b"},
+				QueryParams: QueryParamType{"age": 20, "name": "abc"}, SortParams: SortParamType{"name": 1}, Limit: 10})
+			mctest.AssertEquals(t, key1, key2, "equivalent query cache-keys should be: equal")
+			mctest.AssertEquals(t, strings.HasPrefix(key1, "users:"), true, "cache-key should be prefixed by: users:")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the different cache-keys, by record-id, and use the cache-key override:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{TableName: "users"}, CrudOptionsType{})
+			mctest.AssertEquals(t, crud.readCacheKey(CacheReadById, []string{"1"}) != crud.readCacheKey(CacheReadById, []string{"2"}), true, "record-id cache-keys should be: different")
+			mctest.AssertEquals(t, crud.readCacheKey(CacheReadById, []string{"1"}) != crud.readCacheKey(CacheReadByIds, []string{"1"}), true, "read-type cache-keys should be: different")
+			crud.CacheKey = "users-custom"
+			mctest.AssertEquals(t, crud.readCacheKey(CacheReadById, []string{"1"}), "users-custom", "cache-key should be: users-custom")
+		},
+	})
+
+	mctest.PostTestResult()
+}
+
+func TestCachedRead(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "cacheRead.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const cacheTable = "cache_read_items"
+	_ = CreateTable(sqliteDb, hookTestModel{}, cacheTable, "sqlite3")
+	cache := NewLRUCache(10)
+	newCrud := func() *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: cacheTable},
+			CrudOptionsType{Cache: cache, CacheResult: true, CacheStaleExpire: 60})
+	}
+	createRes := newCrud().Create(ActionParamsType{{"name": "abc"}})
+	value, _ := createRes.Value.(CrudResultType)
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should coalesce the identical in-flight reads:",
+		TestFunc: func() {
+			release := make(chan struct{})
+			calls := int64(0)
+			var wg sync.WaitGroup
+			for i := 0; i < 5; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					readFlights.do("flight-key", func() mcresponse.ResponseMessage {
+						atomic.AddInt64(&calls, 1)
+						<-release
+						return mcresponse.ResponseMessage{Code: "success"}
+					})
+				}()
+			}
+			time.Sleep(20 * time.Millisecond)
+			close(release)
+			wg.Wait()
+			mctest.AssertEquals(t, atomic.LoadInt64(&calls), int64(1), "read-function calls should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should serve the stale result, while refreshing it in the background:",
+		TestFunc: func() {
+			ResetCacheMetrics()
+			crud := newCrud()
+			cacheKey := crud.readCacheKey(CacheReadById, []string{value.RecordIds[0]})
+			staleResult := GetResultType{Records: []map[string]interface{}{{"name": "stale"}}}
+			_ = cache.Set(cacheKey, cacheEntryType{Result: staleResult, FreshUntil: time.Now().Add(-time.Second)}, time.Minute, cacheTable)
+			res := crud.GetById(value.RecordIds[0])
+			result, _ := res.Value.(GetResultType)
+			mctest.AssertEquals(t, result.Records[0]["name"], "stale", "served name should be: stale")
+			time.Sleep(50 * time.Millisecond)
+			res = newCrud().GetById(value.RecordIds[0])
+			result, _ = res.Value.(GetResultType)
+			mctest.AssertEquals(t, result.Records[0]["name"], "abc", "refreshed name should be: abc")
+			metrics := GetCacheMetrics()
+			mctest.AssertEquals(t, metrics.StaleHits, int64(1), "stale-hits should be: 1")
+			mctest.AssertEquals(t, metrics.Misses, int64(1), "misses should be: 1")
+			mctest.AssertEquals(t, metrics.Hits, int64(1), "hits should be: 1")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,107 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: mcdbcrud-gen - generate go model-structs from an existing database (PostgresSQL, MySQL, SQLite3)
+//
+// Usage:
+//
+//	mcdbcrud-gen -config db.json -package models -out models/models_gen.go
+//	mcdbcrud-gen -db-type sqlite3 -filename app.db -tables audits,users -singular
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/abbeymart/mcdbcrud"
+)
+
+func main() {
+	var (
+		configFile  = flag.String("config", "", "DbConfig json file (dbType, host, port, username, password, dbName, filename) or .env file (MCDB_HOST=...)")
+		dbType      = flag.String("db-type", "", "db-type: postgres, mysql, mariadb or sqlite3")
+		host        = flag.String("host", "localhost", "db host")
+		port        = flag.Uint("port", 0, "db port")
+		username    = flag.String("username", "", "db username")
+		password    = flag.String("password", "", "db password (default: $MCDB_PASSWORD or $MCDB_PASSWORD_FILE)")
+		dbName      = flag.String("dbname", "", "db name")
+		filename    = flag.String("filename", "", "sqlite3 db filename")
+		sslMode     = flag.String("sslmode", "", "postgres ssl-mode")
+		packageName = flag.String("package", "models", "generated go package name")
+		tables      = flag.String("tables", "", "comma-separated table names (default: all tables)")
+		singular    = flag.Bool("singular", true, "singular struct-names, e.g. audits => Audit")
+		outFile     = flag.String("out", "", "output file (default: stdout)")
+	)
+	flag.Parse()
+
+	// config-file and MCDB_ environment variables, validated after the command-line flags
+	loadOptions := mcdbcrud.LoadDbConfigOptionsType{SkipVerify: true}
+	if *configFile != "" {
+		loadOptions.Files = []string{*configFile}
+	}
+	dbConfig, err := mcdbcrud.LoadDbConfig(loadOptions)
+	if err != nil {
+		exitError(err.Error())
+	}
+	// command-line flags override the config-file and environment values
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "db-type":
+			dbConfig.DbType = *dbType
+		case "host":
+			dbConfig.Host = *host
+		case "port":
+			dbConfig.Port = uint32(*port)
+		case "username":
+			dbConfig.Username = *username
+		case "password":
+			dbConfig.Password = *password
+		case "dbname":
+			dbConfig.DbName = *dbName
+		case "filename":
+			dbConfig.Filename = *filename
+		case "sslmode":
+			dbConfig.SecureOptions.SslMode = *sslMode
+		}
+	})
+	if dbConfig.Host == "" {
+		dbConfig.Host = *host
+	}
+	if err = dbConfig.Validate(); err != nil {
+		exitError(err.Error())
+	}
+
+	appDb, err := dbConfig.OpenDbx()
+	if err != nil {
+		exitError(err.Error())
+	}
+	defer dbConfig.CloseDbx()
+
+	options := mcdbcrud.GenModelsOptionsType{
+		PackageName: *packageName,
+		Singular:    *singular,
+	}
+	for _, tableName := range strings.Split(*tables, ",") {
+		if tableName = strings.TrimSpace(tableName); tableName != "" {
+			options.TableNames = append(options.TableNames, tableName)
+		}
+	}
+	source, err := mcdbcrud.GenerateModels(appDb, dbConfig.DbType, options)
+	if err != nil {
+		exitError(err.Error())
+	}
+	if *outFile == "" {
+		_, _ = os.Stdout.Write(source)
+		return
+	}
+	if err = os.WriteFile(*outFile, source, 0644); err != nil {
+		exitError(fmt.Sprintf("error writing output file: %v", err.Error()))
+	}
+}
+
+func exitError(message string) {
+	_, _ = fmt.Fprintf(os.Stderr, "mcdbcrud-gen: %v\n", message)
+	os.Exit(1)
+}
@@ -0,0 +1,397 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute create-table (DDL) script, from model-struct (db, json and ddl tags)
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// DdlTag is the struct-tag for DDL column options, separated by semicolon, e.g.
+// `ddl:"size:100;unique"`, `ddl:"index:idx_audit_log"`, `ddl:"default:CURRENT_TIMESTAMP"`, `ddl:"type:NUMERIC(12,2);null"`
+//   - pk: primary key column (default: id field)
+//   - unique[:name]: unique constraint, composite by the same name
+//   - index[:name]: index, composite by the same name
+//   - default:value: column default value/expression, as specified (quote string literals)
+//   - size:n: VARCHAR(n) for string fields
+//   - type:sqlType: overrides the computed column type
+//   - null | notnull: overrides the computed nullability (pointer, interface, map and slice fields are nullable)
+const DdlTag = "ddl"
+
+// ModelColumnType describes the table-column computed from the model-struct field
+type ModelColumnType struct {
+	FieldName  string
+	ColumnName string
+	GoKind     reflect.Kind
+	ColumnType string
+	CustomType bool // column-type specified by the ddl-tag type option
+	Nullable   bool
+	PrimaryKey bool
+	Default    string
+	Unique     string
+	Index      string
+}
+
+type CreateTableQueryObject struct {
+	TableScript  string
+	IndexScripts []string
+}
+
+type CreateTableQueryResult struct {
+	CreateTableQueryObject CreateTableQueryObject
+	Ok                     bool
+	Message                string
+}
+
+// Scripts returns the create-table and create-index scripts, in execution order
+func (q CreateTableQueryObject) Scripts() []string {
+	return append([]string{q.TableScript}, q.IndexScripts...)
+}
+
+// Script returns the combined, semicolon-terminated, DDL script
+func (q CreateTableQueryObject) Script() string {
+	return strings.Join(q.Scripts(), ";\n") + ";"
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// ddlColumnType computes the dialect column-type for the go-type, and the default nullability
+func ddlColumnType(fieldType reflect.Type, size int, dbType string) (string, bool, error) {
+	nullable := false
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+		nullable = true
+	}
+	if fieldType == timeType {
+		switch dbType {
+		case "postgres":
+			return "TIMESTAMPTZ", nullable, nil
+		case "mysql", "mariadb":
+			return "DATETIME", nullable, nil
+		default:
+			return "TIMESTAMP", nullable, nil
+		}
+	}
+	switch fieldType.Kind() {
+	case reflect.String:
+		if size > 0 {
+			return fmt.Sprintf("VARCHAR(%v)", size), nullable, nil
+		}
+		switch dbType {
+		case "mysql", "mariadb":
+			return "VARCHAR(255)", nullable, nil
+		default:
+			return "TEXT", nullable, nil
+		}
+	case reflect.Bool:
+		return "BOOLEAN", nullable, nil
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return "INTEGER", nullable, nil
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		if dbType == "sqlite3" {
+			return "INTEGER", nullable, nil
+		}
+		return "BIGINT", nullable, nil
+	case reflect.Float32:
+		return "REAL", nullable, nil
+	case reflect.Float64:
+		switch dbType {
+		case "postgres":
+			return "DOUBLE PRECISION", nullable, nil
+		case "mysql", "mariadb":
+			return "DOUBLE", nullable, nil
+		default:
+			return "REAL", nullable, nil
+		}
+	case reflect.Slice:
+		if fieldType.Elem().Kind() == reflect.Uint8 {
+			// []byte
+			if dbType == "postgres" {
+				return "BYTEA", true, nil
+			}
+			return "BLOB", true, nil
+		}
+		return ddlJsonType(dbType), true, nil
+	case reflect.Interface, reflect.Map, reflect.Struct, reflect.Array:
+		return ddlJsonType(dbType), true, nil
+	default:
+		return "", false, errors.New(fmt.Sprintf("unsupported field-type: %v", fieldType.String()))
+	}
+}
+
+func ddlJsonType(dbType string) string {
+	switch dbType {
+	case "postgres":
+		return "JSONB"
+	case "mysql", "mariadb":
+		return "JSON"
+	default:
+		return "TEXT"
+	}
+}
+
+// ddlPrimaryKeyType computes the dialect primary-key column-type and the generated default value
+func ddlPrimaryKeyType(kind reflect.Kind, dbType string) (string, string) {
+	if kind == reflect.String {
+		switch dbType {
+		case "postgres":
+			return "UUID", "gen_random_uuid()"
+		case "mysql", "mariadb":
+			return "VARCHAR(36)", "(UUID())"
+		default:
+			return "TEXT", "(lower(hex(randomblob(4))) || '-' || lower(hex(randomblob(2))) || '-' || lower(hex(randomblob(2))) || '-' || lower(hex(randomblob(2))) || '-' || lower(hex(randomblob(6))))"
+		}
+	}
+	switch dbType {
+	case "postgres":
+		return "BIGSERIAL", ""
+	case "mysql", "mariadb":
+		return "BIGINT AUTO_INCREMENT", ""
+	default:
+		return "INTEGER", ""
+	}
+}
+
+// ModelColumns computes the table-columns, by dialect, from the model-struct fields (including embedded structs).
+// Column names are the db-tag values, or the underscore field-names (as in StructToMapUnderscore)
+func ModelColumns(modelRef interface{}, dbType string) ([]ModelColumnType, error) {
+	if modelRef == nil {
+		return nil, errors.New("model-struct is required")
+	}
+	modelType := reflect.TypeOf(modelRef)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, errors.New(fmt.Sprintf("model parameter must be of type struct{}"))
+	}
+	switch dbType {
+	case "postgres", "mysql", "mariadb", "sqlite3":
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown db-type(%v)", dbType))
+	}
+	return modelTypeColumns(modelType, dbType)
+}
+
+func modelTypeColumns(modelType reflect.Type, dbType string) ([]ModelColumnType, error) {
+	var columns []ModelColumnType
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			embeddedColumns, err := modelTypeColumns(field.Type, dbType)
+			if err != nil {
+				return nil, err
+			}
+			columns = append(columns, embeddedColumns...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		columnName := field.Tag.Get("db")
+		if columnName == "-" || field.Tag.Get("json") == "-" && columnName == "" {
+			continue
+		}
+		if columnName == "" {
+			columnName = govalidator.CamelCaseToUnderscore(field.Name)
+		}
+		column := ModelColumnType{
+			FieldName:  field.Name,
+			ColumnName: columnName,
+			GoKind:     field.Type.Kind(),
+			PrimaryKey: columnName == "id",
+		}
+		if column.GoKind == reflect.Ptr {
+			column.GoKind = field.Type.Elem().Kind()
+		}
+		// ddl-tag options
+		size := 0
+		nullOption := ""
+		for _, option := range strings.Split(field.Tag.Get(DdlTag), ";") {
+			option = strings.TrimSpace(option)
+			if option == "" {
+				continue
+			}
+			optionKey, optionValue, _ := strings.Cut(option, ":")
+			switch strings.TrimSpace(optionKey) {
+			case "pk":
+				column.PrimaryKey = true
+			case "unique":
+				column.Unique = strings.TrimSpace(optionValue)
+				if column.Unique == "" {
+					column.Unique = columnName
+				}
+			case "index":
+				column.Index = strings.TrimSpace(optionValue)
+				if column.Index == "" {
+					column.Index = columnName
+				}
+			case "default":
+				column.Default = strings.TrimSpace(optionValue)
+			case "size":
+				size, _ = strconv.Atoi(strings.TrimSpace(optionValue))
+			case "type":
+				column.ColumnType = strings.TrimSpace(optionValue)
+			case "null", "notnull":
+				nullOption = optionKey
+			default:
+				return nil, errors.New(fmt.Sprintf("field[%v]: unknown ddl-tag option(%v)", field.Name, optionKey))
+			}
+		}
+		columnType, nullable, err := ddlColumnType(field.Type, size, dbType)
+		if err != nil && column.ColumnType == "" {
+			return nil, errors.New(fmt.Sprintf("field[%v]: %v", field.Name, err.Error()))
+		}
+		column.CustomType = column.ColumnType != ""
+		if !column.CustomType {
+			column.ColumnType = columnType
+		}
+		column.Nullable = nullable
+		switch nullOption {
+		case "null":
+			column.Nullable = true
+		case "notnull":
+			column.Nullable = false
+		}
+		if column.PrimaryKey {
+			column.Nullable = false
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// ComputeCreateTableQuery computes the dialect (postgres, mysql, mariadb or sqlite3) create-table and create-index scripts,
+// from the model-struct. The scripts use IF NOT EXISTS, and may be applied directly or as migration scripts
+func ComputeCreateTableQuery(modelRef interface{}, tableName string, dbType string) CreateTableQueryResult {
+	if tableName == "" {
+		return CreateTableQueryResult{
+			CreateTableQueryObject: CreateTableQueryObject{},
+			Ok:                     false,
+			Message:                "table-name is required",
+		}
+	}
+	columns, err := ModelColumns(modelRef, dbType)
+	if err != nil {
+		return CreateTableQueryResult{
+			CreateTableQueryObject: CreateTableQueryObject{},
+			Ok:                     false,
+			Message:                fmt.Sprintf("error computing model-columns: %v", err.Error()),
+		}
+	}
+	if len(columns) < 1 {
+		return CreateTableQueryResult{
+			CreateTableQueryObject: CreateTableQueryObject{},
+			Ok:                     false,
+			Message:                "model-struct must include at least one field",
+		}
+	}
+	var (
+		columnScripts []string
+		primaryKeys   []string
+		uniqueNames   []string
+		indexNames    []string
+	)
+	uniqueColumns := map[string][]string{}
+	indexColumns := map[string][]string{}
+	singlePk := 0
+	for _, column := range columns {
+		if column.PrimaryKey {
+			singlePk++
+		}
+	}
+	for _, column := range columns {
+		columnType := column.ColumnType
+		columnDefault := column.Default
+		if column.PrimaryKey && singlePk == 1 && !column.CustomType {
+			pkType, pkDefault := ddlPrimaryKeyType(column.GoKind, dbType)
+			columnType = pkType
+			if columnDefault == "" {
+				columnDefault = pkDefault
+			}
+		}
+		columnScript := fmt.Sprintf("%v %v", column.ColumnName, columnType)
+		if column.PrimaryKey && singlePk == 1 {
+			columnScript += " PRIMARY KEY"
+			if dbType == "sqlite3" && columnType == "INTEGER" {
+				columnScript += " AUTOINCREMENT"
+			}
+		} else if !column.Nullable {
+			columnScript += " NOT NULL"
+		}
+		if columnDefault != "" {
+			columnScript += " DEFAULT " + columnDefault
+		}
+		columnScripts = append(columnScripts, columnScript)
+		if column.PrimaryKey {
+			primaryKeys = append(primaryKeys, column.ColumnName)
+		}
+		if column.Unique != "" {
+			if _, ok := uniqueColumns[column.Unique]; !ok {
+				uniqueNames = append(uniqueNames, column.Unique)
+			}
+			uniqueColumns[column.Unique] = append(uniqueColumns[column.Unique], column.ColumnName)
+		}
+		if column.Index != "" {
+			if _, ok := indexColumns[column.Index]; !ok {
+				indexNames = append(indexNames, column.Index)
+			}
+			indexColumns[column.Index] = append(indexColumns[column.Index], column.ColumnName)
+		}
+	}
+	if singlePk > 1 {
+		columnScripts = append(columnScripts, fmt.Sprintf("PRIMARY KEY (%v)", strings.Join(primaryKeys, ", ")))
+	}
+	// constraint and index names, by the unqualified table-name (e.g. items of acme.items)
+	schemaName, baseTable, qualified := strings.Cut(tableName, ".")
+	if !qualified {
+		baseTable = tableName
+	}
+	sort.Strings(uniqueNames)
+	for _, name := range uniqueNames {
+		columnScripts = append(columnScripts, fmt.Sprintf("CONSTRAINT %v_%v_key UNIQUE (%v)", baseTable, name, strings.Join(uniqueColumns[name], ", ")))
+	}
+	sort.Strings(indexNames)
+	var indexScripts []string
+	for _, name := range indexNames {
+		indexName := fmt.Sprintf("%v_%v_idx", baseTable, name)
+		indexFields := strings.Join(indexColumns[name], ", ")
+		switch dbType {
+		case "mysql", "mariadb":
+			// no CREATE INDEX IF NOT EXISTS for MySQL: create indexes with the table
+			columnScripts = append(columnScripts, fmt.Sprintf("INDEX %v (%v)", indexName, indexFields))
+		case "sqlite3":
+			// sqlite3 qualifies the index-name, not the table-name, by the schema (attached database)
+			if qualified {
+				indexScripts = append(indexScripts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %v.%v ON %v (%v)", schemaName, indexName, baseTable, indexFields))
+			} else {
+				indexScripts = append(indexScripts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %v ON %v (%v)", indexName, tableName, indexFields))
+			}
+		default:
+			indexScripts = append(indexScripts, fmt.Sprintf("CREATE INDEX IF NOT EXISTS %v ON %v (%v)", indexName, tableName, indexFields))
+		}
+	}
+	tableScript := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (%v)", tableName, strings.Join(columnScripts, ", "))
+	return CreateTableQueryResult{
+		CreateTableQueryObject: CreateTableQueryObject{
+			TableScript:  tableScript,
+			IndexScripts: indexScripts,
+		},
+		Ok:      true,
+		Message: "success",
+	}
+}
+
+// ComputeDropTableQuery computes the drop-table script, e.g. for the down-migration
+func ComputeDropTableQuery(tableName string) string {
+	return fmt.Sprintf("DROP TABLE IF EXISTS %v", tableName)
+}
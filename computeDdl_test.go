@@ -0,0 +1,82 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute create-table (DDL) script test cases
+
+package mcdbcrud
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+type ddlTestModel struct {
+	Id       int64       `json:"id" db:"id"`
+	Code     string      `json:"code" db:"code" ddl:"size:20;unique"`
+	OrgId    string      `json:"orgId" db:"org_id" ddl:"index:org_code"`
+	Amount   float64     `json:"amount" db:"amount" ddl:"type:NUMERIC(12,2)"`
+	Meta     interface{} `json:"meta" db:"meta"`
+	ClosedAt *time.Time  `json:"closedAt" db:"closed_at"`
+	Skipped  string      `json:"skipped" db:"-"`
+}
+
+func TestComputeCreateTableQuery(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the postgres create-table script from the model-struct:",
+		TestFunc: func() {
+			res := ComputeCreateTableQuery(ddlTestModel{}, "orders", "postgres")
+			expected := "CREATE TABLE IF NOT EXISTS orders (id BIGSERIAL PRIMARY KEY, code VARCHAR(20) NOT NULL, org_id TEXT NOT NULL, amount NUMERIC(12,2) NOT NULL, meta JSONB, closed_at TIMESTAMPTZ, CONSTRAINT orders_code_key UNIQUE (code))"
+			mctest.AssertEquals(t, res.Ok, true, "create-table-result should be: ok")
+			mctest.AssertEquals(t, res.CreateTableQueryObject.TableScript, expected, "create-table script should be: "+expected)
+			mctest.AssertEquals(t, res.CreateTableQueryObject.IndexScripts[0], "CREATE INDEX IF NOT EXISTS orders_org_code_idx ON orders (org_id)", "create-index script should be: orders_org_code_idx")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the constraint and index names by the unqualified (schema) table-name:",
+		TestFunc: func() {
+			res := ComputeCreateTableQuery(ddlTestModel{}, "acme.orders", "postgres")
+			mctest.AssertEquals(t, strings.Contains(res.CreateTableQueryObject.TableScript, "CONSTRAINT orders_code_key UNIQUE (code)"), true, "unique constraint should be: orders_code_key")
+			mctest.AssertEquals(t, res.CreateTableQueryObject.IndexScripts[0], "CREATE INDEX IF NOT EXISTS orders_org_code_idx ON acme.orders (org_id)", "create-index script should be: orders_org_code_idx")
+			res = ComputeCreateTableQuery(ddlTestModel{}, "acme.orders", "sqlite3")
+			mctest.AssertEquals(t, res.CreateTableQueryObject.IndexScripts[0], "CREATE INDEX IF NOT EXISTS acme.orders_org_code_idx ON orders (org_id)", "sqlite3 create-index script should be: acme.orders_org_code_idx")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the mysql create-table script, with inline indexes:",
+		TestFunc: func() {
+			res := ComputeCreateTableQuery(AuditModel{}, AuditTable, "mysql")
+			expected := "CREATE TABLE IF NOT EXISTS audits (id VARCHAR(36) PRIMARY KEY DEFAULT (UUID()), table_name VARCHAR(255) NOT NULL, log_records JSON, new_log_records JSON, log_type VARCHAR(255) NOT NULL, log_by VARCHAR(255) NOT NULL, log_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP, INDEX audits_log_by_idx (log_by), INDEX audits_log_type_idx (log_type), INDEX audits_table_name_idx (table_name))"
+			mctest.AssertEquals(t, res.CreateTableQueryObject.TableScript, expected, "create-table script should be: "+expected)
+			mctest.AssertEquals(t, len(res.CreateTableQueryObject.IndexScripts), 0, "create-index scripts length should be: 0")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return error for unknown db-type and non-struct model:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, ComputeCreateTableQuery(AuditModel{}, AuditTable, "oracle").Ok, false, "unknown db-type result should be: not ok")
+			mctest.AssertEquals(t, ComputeCreateTableQuery("audit", AuditTable, "postgres").Ok, false, "non-struct model result should be: not ok")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should bootstrap the audit and access tables, by sqlite3 db:",
+		TestFunc: func() {
+			sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "bootstrap.db"))
+			mctest.AssertEquals(t, dbErr, nil, "sqlite3 db-open error should be: nil")
+			defer sqliteDb.Close()
+			mctest.AssertEquals(t, BootstrapTables(sqliteDb, "sqlite3", CrudOptionsType{}), nil, "bootstrap error should be: nil")
+			// repeatable, if not exists
+			mctest.AssertEquals(t, BootstrapTables(sqliteDb, "sqlite3", CrudOptionsType{}), nil, "repeat bootstrap error should be: nil")
+			_, insErr := sqliteDb.Exec("INSERT INTO audits(table_name, log_records, log_type, log_by, log_at) VALUES (?, ?, ?, ?, ?)", "users", "{}", CreateTask, "1", time.Now())
+			mctest.AssertEquals(t, insErr, nil, "audit insert error should be: nil")
+			var id string
+			_ = sqliteDb.QueryRow("SELECT id FROM audits").Scan(&id)
+			mctest.AssertEquals(t, len(id), 36, "generated audit-id length should be: 36")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,158 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute select-SQL scripts for joined tables
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// joinFromQuery composes the FROM ... JOIN ... clause for the base-table and the joined tables
+func joinFromQuery(params JoinSelectQueryParamsType) (string, error) {
+	if params.TableName == "" {
+		return "", errors.New("tableName is required")
+	}
+	fromQuery := fmt.Sprintf("FROM %v", params.TableName)
+	if params.TableAlias != "" {
+		fromQuery += fmt.Sprintf(" %v", params.TableAlias)
+	}
+	for i, join := range params.Joins {
+		if join.TableName == "" || join.OnCondition == "" {
+			return "", errors.New(fmt.Sprintf("joins[%v]: tableName and onCondition are required", i))
+		}
+		joinType := strings.ToUpper(strings.TrimSpace(join.JoinType))
+		switch joinType {
+		case "", "INNER", InnerJoin:
+			joinType = InnerJoin
+		case "LEFT", LeftJoin:
+			joinType = LeftJoin
+		default:
+			return "", errors.New(fmt.Sprintf("joins[%v]: unsupported joinType(%v)", i, join.JoinType))
+		}
+		fromQuery += fmt.Sprintf(" %v %v", joinType, join.TableName)
+		if join.Alias != "" {
+			fromQuery += fmt.Sprintf(" %v", join.Alias)
+		}
+		fromQuery += fmt.Sprintf(" ON %v", join.OnCondition)
+	}
+	return fromQuery, nil
+}
+
+// joinSelectFields composes the projection of the qualified select-fields.
+// A qualified field without an explicit label, e.g. "u.username", is labelled "u.username",
+// for scanning into nested structs (db:"u") or nested maps
+func joinSelectFields(selectFields []string) string {
+	var fields []string
+	for _, field := range selectFields {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if strings.Contains(strings.ToUpper(field), " AS ") || !strings.Contains(field, ".") || strings.HasSuffix(field, ".*") {
+			fields = append(fields, field)
+			continue
+		}
+		fieldName := whereFieldName(field)
+		fields = append(fields, fmt.Sprintf(`%v AS "%v"`, fieldName, fieldName))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// joinSortQuery composes the ORDER BY clause from the (qualified) sort-params, in field-name order
+func joinSortQuery(sortParams SortParamType) string {
+	if len(sortParams) < 1 {
+		return ""
+	}
+	var fieldNames []string
+	for fieldName := range sortParams {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	var sortFields []string
+	for _, fieldName := range fieldNames {
+		order := "ASC"
+		if sortParams[fieldName] < 0 {
+			order = "DESC"
+		}
+		sortFields = append(sortFields, fmt.Sprintf("%v %v", whereFieldName(fieldName), order))
+	}
+	return " ORDER BY " + strings.Join(sortFields, ", ")
+}
+
+// ComputeSelectQueryJoin compose the SELECT query for the base-table and the joined tables,
+// constrained by the optional (qualified) where-conditions, sort, skip(offset) and limit options
+func ComputeSelectQueryJoin(params JoinSelectQueryParamsType) SelectQueryResult {
+	if params.TableName == "" || len(params.SelectFields) < 1 {
+		return selectErrMessage("tableName and selectFields are required.")
+	}
+	fromQuery, fromErr := joinFromQuery(params)
+	if fromErr != nil {
+		return selectErrMessage(fromErr.Error())
+	}
+	fieldText := joinSelectFields(params.SelectFields)
+	if fieldText == "" {
+		return selectErrMessage("valid selectFields are required.")
+	}
+	selectQuery := fmt.Sprintf("SELECT %v %v", fieldText, fromQuery)
+	var fieldValues []interface{}
+	whereQuery := WhereQueryObject{}
+	if len(params.QueryParams) > 0 {
+		whereRes := ComputeWhereQuery(params.QueryParams, 1)
+		if !whereRes.Ok {
+			return selectErrMessage(fmt.Sprintf("error computing where-query condition(s): %v", whereRes.Message))
+		}
+		whereQuery = whereRes.WhereQueryObject
+		selectQuery += " " + whereQuery.WhereQuery
+		fieldValues = whereQuery.FieldValues
+	}
+	selectQuery += joinSortQuery(params.SortParams)
+	// adjust selectQuery for skip and limit options
+	if params.Limit > 0 {
+		selectQuery += fmt.Sprintf(" LIMIT %v", params.Limit)
+	}
+	if params.Skip > 0 {
+		selectQuery += fmt.Sprintf(" OFFSET %v", params.Skip)
+	}
+	return SelectQueryResult{
+		SelectQueryObject: SelectQueryObject{
+			SelectQuery: selectQuery,
+			FieldValues: fieldValues,
+			WhereQuery:  whereQuery,
+		},
+		Ok:      true,
+		Message: "success",
+	}
+}
+
+// ComputeCountQueryJoin compose the COUNT query matching the joins and where-conditions of the select-query
+func ComputeCountQueryJoin(params JoinSelectQueryParamsType) SelectQueryResult {
+	fromQuery, fromErr := joinFromQuery(params)
+	if fromErr != nil {
+		return selectErrMessage(fromErr.Error())
+	}
+	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows %v", fromQuery)
+	var fieldValues []interface{}
+	whereQuery := WhereQueryObject{}
+	if len(params.QueryParams) > 0 {
+		whereRes := ComputeWhereQuery(params.QueryParams, 1)
+		if !whereRes.Ok {
+			return selectErrMessage(fmt.Sprintf("error computing where-query condition(s): %v", whereRes.Message))
+		}
+		whereQuery = whereRes.WhereQueryObject
+		countQuery += " " + whereQuery.WhereQuery
+		fieldValues = whereQuery.FieldValues
+	}
+	return SelectQueryResult{
+		SelectQueryObject: SelectQueryObject{
+			SelectQuery: countQuery,
+			FieldValues: fieldValues,
+			WhereQuery:  whereQuery,
+		},
+		Ok:      true,
+		Message: "success",
+	}
+}
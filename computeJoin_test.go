@@ -0,0 +1,67 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: compute join select/count queries test cases
+
+package mcdbcrud
+
+import (
+	"github.com/abbeymart/mctest"
+	"testing"
+)
+
+func TestComputeJoin(t *testing.T) {
+	joinParams := JoinSelectQueryParamsType{
+		TableName:  AuditTable,
+		TableAlias: "a",
+		Joins: []JoinParamType{
+			{JoinType: LeftJoin, TableName: "users", Alias: "u", OnCondition: "u.id = a.log_by"},
+		},
+		SelectFields: []string{"a.id", "a.logType", "u.username", "u.email AS email"},
+		QueryParams:  QueryParamType{"a.logType": "create"},
+		SortParams:   SortParamType{"a.logAt": -1},
+		Skip:         10,
+		Limit:        20,
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the join select-query with qualified fields, where, sort, limit and offset:",
+		TestFunc: func() {
+			res := ComputeSelectQueryJoin(joinParams)
+			expected := `SELECT a.id AS "a.id", a.log_type AS "a.log_type", u.username AS "u.username", u.email AS email FROM audits a LEFT JOIN users u ON u.id = a.log_by WHERE a.log_type=$1 ORDER BY a.log_at DESC LIMIT 20 OFFSET 10`
+			mctest.AssertEquals(t, res.Ok, true, "select-query-result should be: ok")
+			mctest.AssertEquals(t, res.SelectQueryObject.SelectQuery, expected, "select-query should be: "+expected)
+			mctest.AssertEquals(t, len(res.SelectQueryObject.FieldValues), 1, "select-query field-values length should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should compute the matching join count-query:",
+		TestFunc: func() {
+			res := ComputeCountQueryJoin(joinParams)
+			expected := `SELECT COUNT(*) AS total_rows FROM audits a LEFT JOIN users u ON u.id = a.log_by WHERE a.log_type=$1`
+			mctest.AssertEquals(t, res.Ok, true, "count-query-result should be: ok")
+			mctest.AssertEquals(t, res.SelectQueryObject.SelectQuery, expected, "count-query should be: "+expected)
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return error for join without on-condition:",
+		TestFunc: func() {
+			params := joinParams
+			params.Joins = []JoinParamType{{TableName: "users", Alias: "u"}}
+			res := ComputeSelectQueryJoin(params)
+			mctest.AssertEquals(t, res.Ok, false, "select-query-result should be: not ok")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should transform map-scanned row into nested map-record by table-alias:",
+		TestFunc: func() {
+			rec := nestedMapRecord(map[string]interface{}{"a.log_type": []byte("create"), "u.username": "abbeymart", "email": "abc@xyz.com"}, "_")
+			userRec, _ := rec["u"].(map[string]interface{})
+			auditRec, _ := rec["a"].(map[string]interface{})
+			mctest.AssertEquals(t, userRec["username"], "abbeymart", "nested user-username should be: abbeymart")
+			mctest.AssertEquals(t, auditRec["logType"], "create", "nested audit-logType should be: create")
+			mctest.AssertEquals(t, rec["email"], "abc@xyz.com", "top-level email should be: abc@xyz.com")
+		},
+	})
+
+	mctest.PostTestResult()
+}
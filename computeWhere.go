@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"github.com/asaskevich/govalidator"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -23,6 +24,16 @@ func whereErrMessage(errMsg string) WhereQueryResult {
 	}
 }
 
+// whereFieldName converts the camelCase field-name to the underscore table-field,
+// preserving the table-alias qualifier, if any (e.g. "u.isActive" => "u.is_active")
+func whereFieldName(fieldName string) string {
+	fieldParts := strings.Split(fieldName, ".")
+	for i, part := range fieldParts {
+		fieldParts[i] = govalidator.CamelCaseToUnderscore(part)
+	}
+	return strings.Join(fieldParts, ".")
+}
+
 // ComputeWhereQuery function computes the multi-cases where-conditions for crud-operations
 func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryResult {
 	if len(queryParams) < 1 || fieldLength < 1 {
@@ -44,38 +55,26 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 				if fVal2, ok2 := fieldValue.([]interface{}); !ok2 {
 					return whereErrMessage(fmt.Sprintf("field_name: %v [slice-type] | field_value: %v error: ", fieldName, fieldValue))
 				} else {
-					// compute IN clause for []interface{} (string and other values)
-					idLen := len(fVal2)
-					recIds := "("
-					for i, val := range fVal2 {
-						if valStr, valStrOk := val.(string); valStrOk {
-							recIds += fmt.Sprintf("'%v'", valStr)
-						} else {
-							recIds += fmt.Sprintf("%v", val)
-						}
-						if idLen > 1 && i < idLen-1 {
-							recIds += ", "
-						}
+					// compute IN clause for []interface{} (string and other values), by placeholder-values
+					var placeholders []string
+					for _, val := range fVal2 {
+						fieldValues = append(fieldValues, val)
+						placeholders = append(placeholders, fmt.Sprintf("$%v", fieldLength))
+						fieldLength += 1
 					}
-					recIds += ")"
-					// fieldValues.push(`${recIds}`)
-					fieldNameUnderscore := govalidator.CamelCaseToUnderscore(fieldName)
-					whereQuery += fmt.Sprintf("%v IN %v", fieldNameUnderscore, recIds)
+					fieldNameUnderscore := whereFieldName(fieldName)
+					whereQuery += fmt.Sprintf("%v IN (%v)", fieldNameUnderscore, strings.Join(placeholders, ", "))
 				}
 			} else {
-				// compute IN clause for []string
-				idLen := len(fVal)
-				recIds := "("
-				for i, val := range fVal {
-					recIds += "'" + val + "'"
-					if i < idLen-1 {
-						recIds += ", "
-					}
+				// compute IN clause for []string, by placeholder-values
+				var placeholders []string
+				for _, val := range fVal {
+					fieldValues = append(fieldValues, val)
+					placeholders = append(placeholders, fmt.Sprintf("$%v", fieldLength))
+					fieldLength += 1
 				}
-				recIds += ")"
-				// fieldValues.push(`${recIds}`)
-				fieldNameUnderscore := govalidator.CamelCaseToUnderscore(fieldName)
-				whereQuery += fmt.Sprintf("%v IN %v", fieldNameUnderscore, recIds)
+				fieldNameUnderscore := whereFieldName(fieldName)
+				whereQuery += fmt.Sprintf("%v IN (%v)", fieldNameUnderscore, strings.Join(placeholders, ", "))
 			}
 		default:
 			switch fieldValue.(type) {
@@ -85,7 +84,7 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 				} else {
 					currentFieldValue = "'" + fVal.Format("2006-01-02 15:04:05.000000") + "'"
 					fieldValues = append(fieldValues, currentFieldValue)
-					whereQuery += fmt.Sprintf("%v=$%v", govalidator.CamelCaseToUnderscore(fieldName), fieldLength)
+					whereQuery += fmt.Sprintf("%v=$%v", whereFieldName(fieldName), fieldLength)
 				}
 			case string:
 				if fVal, ok := fieldValue.(string); !ok {
@@ -95,7 +94,7 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 						//fmt.Printf("string-toJson-value: %v\n\n", fVal)
 						currentFieldValue = fVal
 						fieldValues = append(fieldValues, currentFieldValue)
-						whereQuery += fmt.Sprintf("%v=$%v", govalidator.CamelCaseToUnderscore(fieldName), fieldLength)
+						whereQuery += fmt.Sprintf("%v=$%v", whereFieldName(fieldName), fieldLength)
 						//if fValue, jErr := govalidator.ToJSON(fieldValue); jErr != nil {
 						//	return whereErrMessage(fmt.Sprintf("field_name: %v | field_value: %v error: ", fieldName, fieldValue))
 						//} else {
@@ -104,13 +103,13 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 						//currentFieldValue = "'" + fVal + "'"
 						currentFieldValue = fVal
 						fieldValues = append(fieldValues, currentFieldValue)
-						whereQuery += fmt.Sprintf("%v=$%v", govalidator.CamelCaseToUnderscore(fieldName), fieldLength)
+						whereQuery += fmt.Sprintf("%v=$%v", whereFieldName(fieldName), fieldLength)
 					}
 				}
 			case int, uint, float32, float64, bool:
 				currentFieldValue = fieldValue
 				fieldValues = append(fieldValues, currentFieldValue)
-				whereQuery += fmt.Sprintf("%v=$%v", govalidator.CamelCaseToUnderscore(fieldName), fieldLength)
+				whereQuery += fmt.Sprintf("%v=$%v", whereFieldName(fieldName), fieldLength)
 			default:
 				// json-stringify fieldValue
 				if fVal, err := json.Marshal(fieldValue); err != nil {
@@ -118,10 +117,10 @@ func ComputeWhereQuery(queryParams QueryParamType, fieldLength int) WhereQueryRe
 				} else {
 					currentFieldValue = fVal
 					fieldValues = append(fieldValues, currentFieldValue)
-					whereQuery += fmt.Sprintf("%v=$%v", govalidator.CamelCaseToUnderscore(fieldName), fieldLength)
+					whereQuery += fmt.Sprintf("%v=$%v", whereFieldName(fieldName), fieldLength)
 				}
 			}
-			// compute next fieldLength (where position), excluding []sting/interface{} case
+			// compute next fieldLength (where position), the []string/[]interface{} case by values
 			fieldLength += 1
 		}
 		// update fieldCount for all queryParams
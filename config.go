@@ -0,0 +1,225 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: DbConfig loading (json / .env files and prefixed environment variables), validation and redaction
+
+package mcdbcrud
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultEnvPrefix is the DbConfig environment variables prefix, e.g. MCDB_HOST
+const DefaultEnvPrefix = "MCDB_"
+
+// LoadDbConfigOptionsType is the DbConfig loading options
+type LoadDbConfigOptionsType struct {
+	Files      []string // json (.json) or .env-style (KEY=VALUE) files, applied in order
+	EnvPrefix  string   // default: MCDB_
+	IgnoreEnv  bool     // skips the environment variables
+	SkipVerify bool     // skips the DbConfig validation
+}
+
+// dbConfigEnvSetters maps the (un-prefixed) environment keys to the DbConfig field setters
+var dbConfigEnvSetters = map[string]func(dbConfig *DbConfig, value string) error{
+	"DB_TYPE":       func(c *DbConfig, v string) error { c.DbType = v; return nil },
+	"HOST":          func(c *DbConfig, v string) error { c.Host = v; return nil },
+	"USERNAME":      func(c *DbConfig, v string) error { c.Username = v; return nil },
+	"PASSWORD":      func(c *DbConfig, v string) error { c.Password = v; return nil },
+	"PASSWORD_FILE": func(c *DbConfig, v string) error { c.PasswordFile = v; return nil },
+	"DB_NAME":       func(c *DbConfig, v string) error { c.DbName = v; return nil },
+	"FILENAME":      func(c *DbConfig, v string) error { c.Filename = v; return nil },
+	"NAME":          func(c *DbConfig, v string) error { c.Name = v; return nil },
+	"TIMEZONE":      func(c *DbConfig, v string) error { c.Timezone = v; return nil },
+	"SSL_MODE":      func(c *DbConfig, v string) error { c.SecureOptions.SslMode = v; return nil },
+	"SSL_CERT":      func(c *DbConfig, v string) error { c.SecureOptions.SecureCert = v; return nil },
+	"SSL_KEY":       func(c *DbConfig, v string) error { c.SecureOptions.SecureKey = v; return nil },
+	"SSL_ROOT_CERT": func(c *DbConfig, v string) error { c.SecureOptions.RootCert = v; return nil },
+	"PORT":          func(c *DbConfig, v string) error { return parseUintValue(v, 32, func(n uint64) { c.Port = uint32(n) }) },
+	"POOL_SIZE": func(c *DbConfig, v string) error {
+		return parseUintValue(v, 32, func(n uint64) { c.PoolSize = uint(n) })
+	},
+	"MAX_IDLE_CONNS":     func(c *DbConfig, v string) error { return parseIntValue(v, func(n int) { c.MaxIdleConns = n }) },
+	"CONN_MAX_LIFETIME":  func(c *DbConfig, v string) error { return parseIntValue(v, func(n int) { c.ConnMaxLifetime = n }) },
+	"CONN_MAX_IDLE_TIME": func(c *DbConfig, v string) error { return parseIntValue(v, func(n int) { c.ConnMaxIdleTime = n }) },
+	"PERMIT_DB_URL": func(c *DbConfig, v string) error {
+		permit, err := strconv.ParseBool(v)
+		if err != nil {
+			return err
+		}
+		c.PermitDBUrl = permit
+		return nil
+	},
+}
+
+func parseUintValue(value string, bitSize int, setter func(n uint64)) error {
+	n, err := strconv.ParseUint(value, 10, bitSize)
+	if err != nil {
+		return err
+	}
+	setter(n)
+	return nil
+}
+
+func parseIntValue(value string, setter func(n int)) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return err
+	}
+	setter(n)
+	return nil
+}
+
+// LoadDbConfig loads the DbConfig, by precedence (lowest to highest): the config files (in order),
+// the prefixed environment variables, and the password file (PasswordFile, e.g. a secret mount).
+// DATABASE_URL still applies at connection, if PermitDBUrl
+func LoadDbConfig(options LoadDbConfigOptionsType) (DbConfig, error) {
+	dbConfig := DbConfig{}
+	envPrefix := options.EnvPrefix
+	if envPrefix == "" {
+		envPrefix = DefaultEnvPrefix
+	}
+	// config files
+	for _, file := range options.Files {
+		if err := dbConfig.loadFile(file, envPrefix); err != nil {
+			return dbConfig, err
+		}
+	}
+	// environment variables
+	if !options.IgnoreEnv {
+		values := map[string]string{}
+		for _, env := range os.Environ() {
+			if key, value, ok := strings.Cut(env, "="); ok {
+				values[key] = value
+			}
+		}
+		if err := dbConfig.applyEnvValues(values, envPrefix, "environment"); err != nil {
+			return dbConfig, err
+		}
+	}
+	// password file
+	if dbConfig.PasswordFile != "" {
+		password, err := os.ReadFile(dbConfig.PasswordFile)
+		if err != nil {
+			return dbConfig, errors.New(fmt.Sprintf("password-file error: %v", err.Error()))
+		}
+		dbConfig.Password = strings.TrimRight(string(password), "\r\n")
+	}
+	if !options.SkipVerify {
+		if err := dbConfig.Validate(); err != nil {
+			return dbConfig, err
+		}
+	}
+	return dbConfig, nil
+}
+
+// loadFile applies the json (.json) or .env-style (prefixed KEY=VALUE lines) config file values
+func (dbConfig *DbConfig) loadFile(file string, envPrefix string) error {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return errors.New(fmt.Sprintf("config-file error: %v", err.Error()))
+	}
+	if strings.EqualFold(filepath.Ext(file), ".json") {
+		if err = json.Unmarshal(content, dbConfig); err != nil {
+			return errors.New(fmt.Sprintf("config-file[%v] error: %v", file, err.Error()))
+		}
+		return nil
+	}
+	values := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return errors.New(fmt.Sprintf("config-file[%v] error: invalid line %v", file, lineNumber))
+		}
+		value = strings.TrimSpace(value)
+		if len(value) > 1 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return dbConfig.applyEnvValues(values, envPrefix, file)
+}
+
+// applyEnvValues applies the prefixed key-values (e.g. MCDB_HOST) to the DbConfig fields
+func (dbConfig *DbConfig) applyEnvValues(values map[string]string, envPrefix string, source string) error {
+	for key, value := range values {
+		if !strings.HasPrefix(key, envPrefix) {
+			continue
+		}
+		setter, ok := dbConfigEnvSetters[strings.TrimPrefix(key, envPrefix)]
+		if !ok {
+			continue
+		}
+		if err := setter(dbConfig, value); err != nil {
+			return errors.New(fmt.Sprintf("%v[%v] error: invalid value: %v", source, key, err.Error()))
+		}
+	}
+	return nil
+}
+
+// Validate returns the error naming the missing (required) fields of the db-type
+func (dbConfig DbConfig) Validate() error {
+	var missingFields []string
+	switch dbConfig.DbType {
+	case "":
+		missingFields = append(missingFields, "dbType")
+	case "sqlite3":
+		if dbConfig.Filename == "" {
+			missingFields = append(missingFields, "filename")
+		}
+	case "postgres", "mysql", "mariadb":
+		// DATABASE_URL provides the connection details
+		if dbConfig.PermitDBUrl && os.Getenv("DATABASE_URL") != "" {
+			return nil
+		}
+		if dbConfig.Host == "" {
+			missingFields = append(missingFields, "host")
+		}
+		if dbConfig.Username == "" {
+			missingFields = append(missingFields, "username")
+		}
+		if dbConfig.DbName == "" {
+			missingFields = append(missingFields, "dbName")
+		}
+	default:
+		return errors.New(fmt.Sprintf("invalid db-config: unknown db-type(%v)", dbConfig.DbType))
+	}
+	if len(missingFields) > 0 {
+		return errors.New(fmt.Sprintf("invalid db-config: missing fields: %v", strings.Join(missingFields, ", ")))
+	}
+	return nil
+}
+
+// String returns the DbConfig information, with the credentials (password, url) redacted
+func (dbConfig DbConfig) String() string {
+	redact := func(value string) string {
+		if value == "" {
+			return ""
+		}
+		return "[REDACTED]"
+	}
+	return fmt.Sprintf("DbConfig{DbType: %v, Host: %v, Port: %v, Username: %v, Password: %v, DbName: %v, Filename: %v, Name: %v, Url: %v, Timezone: %v, SslMode: %v, PoolSize: %v, PermitDBUrl: %v}",
+		dbConfig.DbType, dbConfig.Host, dbConfig.Port, dbConfig.Username, redact(dbConfig.Password), dbConfig.DbName,
+		dbConfig.Filename, dbConfig.Name, redact(dbConfig.Url), dbConfig.Timezone, dbConfig.SecureOptions.SslMode,
+		dbConfig.PoolSize, dbConfig.PermitDBUrl)
+}
+
+// GoString returns the redacted DbConfig information, for the %#v format
+func (dbConfig DbConfig) GoString() string {
+	return dbConfig.String()
+}
@@ -5,7 +5,6 @@
 package mcdbcrud
 
 import (
-	"encoding/json"
 	"fmt"
 	"github.com/abbeymart/mcresponse"
 	"time"
@@ -19,7 +18,12 @@ type Crud struct {
 	UpdateItems    ActionParamsType
 	CurrentRecords []map[string]interface{}
 	TransLog       LogParamX
-	CacheKey       string // Unique for exactly the same query
+	CacheKey       string // cache-key override, for all reads; computed per read (ComputeCacheKey), if empty
+	primaryRead    bool   // read-your-writes, after/within a write of the crud-instance
+	tenant         TenantStoreType
+	tenantErr      error
+	tenantQualify  func(tableName string) string // tenant table-prefix or schema qualifier, of the applyTenant
+	schemaErr      error                         // strict-schema drift or check error, returned by the crud-operations
 }
 
 // NewCrud constructor returns a new crud-instance
@@ -41,6 +45,9 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.Skip = params.Skip
 	crudInstance.Limit = params.Limit
 	crudInstance.AppParams = params.AppParams
+	crudInstance.ReadYourWrites = params.ReadYourWrites
+	crudInstance.PermittedAppIds = params.PermittedAppIds
+	crudInstance.Schema = params.Schema
 
 	// crud options
 	crudInstance.MaxQueryLimit = options.MaxQueryLimit
@@ -52,6 +59,7 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.ProfileTable = options.ProfileTable
 	crudInstance.ServiceTable = options.ServiceTable
 	crudInstance.UserRoleTable = options.UserRoleTable
+	crudInstance.RoleParentTable = options.RoleParentTable
 	crudInstance.AuditDb = options.AuditDb
 	crudInstance.AccessDb = options.AccessDb
 	crudInstance.LogCrud = options.LogCrud
@@ -59,15 +67,34 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	crudInstance.LogCreate = options.LogCreate
 	crudInstance.LogUpdate = options.LogUpdate
 	crudInstance.LogDelete = options.LogDelete
+	crudInstance.LogLogin = options.LogLogin
+	crudInstance.LogLogout = options.LogLogout
+	crudInstance.LoginTimeout = options.LoginTimeout
+	crudInstance.TokenSecret = options.TokenSecret
 	crudInstance.CheckAccess = options.CheckAccess // Dec 09/2020: user to implement auth as a middleware
 	crudInstance.CacheResult = options.CacheResult
 	crudInstance.CacheExpire = options.CacheExpire // cache expire in secs
+	crudInstance.CacheStaleExpire = options.CacheStaleExpire
 	crudInstance.BulkCreate = options.BulkCreate
 	crudInstance.ModelOptions = options.ModelOptions
 	crudInstance.FieldSeparator = options.FieldSeparator
 	crudInstance.AppDbs = options.AppDbs
 	crudInstance.AppTables = options.AppTables
 	crudInstance.QueryFieldType = options.QueryFieldType
+	crudInstance.StrictSchema = options.StrictSchema
+	crudInstance.Hooks = options.Hooks
+	crudInstance.Outbox = options.Outbox
+	crudInstance.OutboxTable = options.OutboxTable
+	crudInstance.InvalidationBus = options.InvalidationBus
+	crudInstance.OnError = options.OnError
+	crudInstance.Cache = options.Cache
+	crudInstance.CacheKeyFunc = options.CacheKeyFunc
+	crudInstance.DisablePreparedStatements = options.DisablePreparedStatements
+	crudInstance.ReadReplicas = options.ReadReplicas
+	crudInstance.Tenancy = options.Tenancy
+	crudInstance.AppIdFilter = options.AppIdFilter
+	crudInstance.TenantLocalAudit = options.TenantLocalAudit
+	crudInstance.TenantLocalAccess = options.TenantLocalAccess
 
 	// Default values
 	if crudInstance.QueryFieldType == "" {
@@ -105,12 +132,38 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	if crudInstance.ServiceTable == "" {
 		crudInstance.ServiceTable = "services"
 	}
+	if crudInstance.UserRoleTable == "" {
+		crudInstance.UserRoleTable = "user_roles"
+	}
+	if crudInstance.RoleParentTable == "" {
+		crudInstance.RoleParentTable = "role_parents"
+	}
+	if crudInstance.Cache == nil {
+		crudInstance.Cache = DefaultCache
+	}
+	if crudInstance.OutboxTable == "" {
+		crudInstance.OutboxTable = DefaultOutboxTable
+	}
+	// registered (DefaultDbRegistry) app, audit and access databases, if not specified
+	if crudInstance.AppDb == nil {
+		crudInstance.AppDb, _ = DefaultDbRegistry.Get(AppDbName)
+	}
+	if crudInstance.AuditDb == nil {
+		crudInstance.AuditDb, _ = DefaultDbRegistry.Get(AuditDbName)
+	}
 	if crudInstance.AuditDb == nil {
 		crudInstance.AuditDb = crudInstance.AppDb
 	}
+	if crudInstance.AccessDb == nil {
+		crudInstance.AccessDb, _ = DefaultDbRegistry.Get(AccessDbName)
+	}
 	if crudInstance.AccessDb == nil {
 		crudInstance.AccessDb = crudInstance.AppDb
 	}
+	// multi-tenancy: the tenant data-store (AppDb, ReadReplicas, TableName prefix or schema), or the per-call schema
+	if crudInstance.Tenancy != nil || crudInstance.Schema != "" {
+		crudInstance.applyTenant()
+	}
 	if crudInstance.Skip < 0 {
 		crudInstance.Skip = 0
 	}
@@ -126,13 +179,13 @@ func NewCrud(params CrudParamsType, options CrudOptionsType) (crudInstance *Crud
 	if crudInstance.CacheExpire <= 0 {
 		crudInstance.CacheExpire = 300 // 300 secs, 5 minutes
 	}
-	// Compute CacheKey from TableName, QueryParams, SortParams, ProjectParams and RecordIds
-	qParam, _ := json.Marshal(params.QueryParams)
-	sParam, _ := json.Marshal(params.SortParams)
-	pParam, _ := json.Marshal(params.ProjectParams)
-	dIds, _ := json.Marshal(params.RecordIds)
-	//crudInstance.CacheKey = params.TableName + string(qParam) + string(sParam) + string(pParam) + string(dIds)
-	crudInstance.CacheKey = fmt.Sprintf("%v-%v-%v-%v-%v-%v-%v", params.TableName, string(qParam), string(sParam), string(pParam), string(dIds), crudInstance.Skip, crudInstance.Limit)
+	if crudInstance.LoginTimeout <= 0 {
+		crudInstance.LoginTimeout = DefaultLoginTimeout // secs
+	}
+	// strict-schema: fail fast on model/table drift (by the crud-operations), checked once per db-connection and table
+	if crudInstance.StrictSchema {
+		crudInstance.schemaErr = checkStrictSchema(crudInstance.AppDb, crudInstance.ModelRef, crudInstance.TableName)
+	}
 
 	// Audit/TransLog instance
 	crudInstance.TransLog = NewAuditLogx(crudInstance.AuditDb, crudInstance.AuditTable)
@@ -420,3 +473,8 @@ func (crud *Crud) GetRecords() mcresponse.ResponseMessage {
 	}
 	return crud.GetAll()
 }
+
+// Err returns the crud-instance construction error (e.g. the strict-schema model/table drift), if any
+func (crud *Crud) Err() error {
+	return crud.schemaErr
+}
@@ -7,75 +7,54 @@ package mcdbcrud
 import (
 	"errors"
 	"fmt"
+	_ "github.com/go-sql-driver/mysql"
 	"github.com/jmoiron/sqlx"
 	_ "github.com/lib/pq"
 	_ "github.com/mattn/go-sqlite3"
-	"os"
-)
-
-var (
-	dbx *sqlx.DB
+	"time"
 )
 
+// OpenDbx opens (or returns the already opened) db-connection pool of the dbConfig, registered in the
+// DefaultDbRegistry by the dbConfig connection-name
 func (dbConfig DbConfig) OpenDbx() (*sqlx.DB, error) {
-	sslMode := dbConfig.SecureOptions.SslMode
-	sslCert := dbConfig.SecureOptions.SecureCert
-	if sslMode == "" {
-		sslMode = "disable"
+	return DefaultDbRegistry.Open(dbConfig.ConnectionName(), dbConfig)
+}
+
+// openDbx opens a new db-connection pool, by the driver DSN (see DataSourceName), with the dbConfig pool settings
+func (dbConfig DbConfig) openDbx() (*sqlx.DB, error) {
+	connectionString, err := dbConfig.DataSourceName()
+	if err != nil {
+		return nil, err
 	}
-	switch dbConfig.DbType {
-	case "postgres":
-		connectionString := ""
-		if sslCert != "" {
-			connectionString = fmt.Sprintf("postgresql://%v:%v@%v:%v/%v?sslmode=%v;sslrootcert=%v", dbConfig.Username, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.DbName, sslMode, sslCert)
-			//connectionString = fmt.Sprintf("port=%d host=%s user=%s password=%s dbname=%s sslmode=%v sslrootcert=%v", dbConfig.Port, dbConfig.Host, dbConfig.Username, dbConfig.Password, dbConfig.DbName, sslMode, sslCert)
-		} else {
-			connectionString = fmt.Sprintf("postgresql://%v:%v@%v:%v/%v?sslmode=%v", dbConfig.Username, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.DbName, sslMode)
-		}
-		if os.Getenv("DATABASE_URL") != "" && dbConfig.PermitDBUrl {
-			connectionString = os.Getenv("DATABASE_URL")
-		}
-		dbx, err = sqlx.Open(dbConfig.DbType, connectionString)
-		if err != nil {
-			errMsg := fmt.Sprintf("Database Connection Error: %v", err.Error())
-			return nil, errors.New(errMsg)
-		}
-		return dbx, nil
-	case "mysql", "mariadb":
-		connectionString := ""
-		if sslCert != "" {
-			connectionString = fmt.Sprintf("mysql://%v:%v@%v:%v/%v?sslmode=%v;sslrootcert=%v", dbConfig.Username, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.DbName, sslMode, sslCert)
-			//connectionString = fmt.Sprintf("port=%d host=%s user=%s password=%s dbname=%s sslmode=%v sslrootcert=%v", dbConfig.Port, dbConfig.Host, dbConfig.Username, dbConfig.Password, dbConfig.DbName, sslMode, sslCert)
-		} else {
-			connectionString = fmt.Sprintf("mysql://%v:%v@%v:%v/%v?sslmode=%v", dbConfig.Username, dbConfig.Password, dbConfig.Host, dbConfig.Port, dbConfig.DbName, sslMode)
-		}
-		if os.Getenv("DATABASE_URL") != "" && dbConfig.PermitDBUrl {
-			connectionString = os.Getenv("DATABASE_URL")
-		}
-		dbx, err = sqlx.Open(dbConfig.DbType, connectionString)
-		if err != nil {
-			errMsg := fmt.Sprintf("Database Connection Error: %v", err.Error())
-			return nil, errors.New(errMsg)
-		}
-		return dbx, nil
-	case "sqlite3":
-		dbx, err = sqlx.Open(dbConfig.DbType, dbConfig.Filename)
-		if err != nil {
-			errMsg := fmt.Sprintf("Database Connection Error: %v", err.Error())
-			return nil, errors.New(errMsg)
-		}
-		return dbx, nil
-	default:
-		return nil, errors.New(fmt.Sprintf("unknown db-type(%v)", dbConfig.DbType))
+	dbx, err := sqlx.Open(dbConfig.DriverName(), connectionString)
+	if err != nil {
+		errMsg := fmt.Sprintf("Database Connection Error: %v", err.Error())
+		return nil, errors.New(errMsg)
 	}
+	dbConfig.applyPoolSettings(dbx)
+	return dbx, nil
 }
 
+// CloseDbx closes the db-connection pool of the dbConfig (opened by OpenDbx/OpenDb)
 func (dbConfig DbConfig) CloseDbx() {
-	if dbx != nil {
-		err = dbx.Close()
-		if err != nil {
-			// log error to the console
-			fmt.Println(err)
-		}
+	if err := DefaultDbRegistry.Close(dbConfig.ConnectionName()); err != nil {
+		// log error to the console
+		fmt.Println(err)
+	}
+}
+
+// applyPoolSettings applies the dbConfig pool settings (PoolSize, MaxIdleConns, ConnMaxLifetime, ConnMaxIdleTime)
+func (dbConfig DbConfig) applyPoolSettings(dbx *sqlx.DB) {
+	if dbConfig.PoolSize > 0 {
+		dbx.SetMaxOpenConns(int(dbConfig.PoolSize))
+	}
+	if dbConfig.MaxIdleConns > 0 {
+		dbx.SetMaxIdleConns(dbConfig.MaxIdleConns)
+	}
+	if dbConfig.ConnMaxLifetime > 0 {
+		dbx.SetConnMaxLifetime(time.Duration(dbConfig.ConnMaxLifetime) * time.Second)
+	}
+	if dbConfig.ConnMaxIdleTime > 0 {
+		dbx.SetConnMaxIdleTime(time.Duration(dbConfig.ConnMaxIdleTime) * time.Second)
 	}
 }
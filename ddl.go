@@ -0,0 +1,150 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: create tables from model-structs, and bootstrap the built-in audit and access tables
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abbeymart/mcdbcrud/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+// AuditModel is the built-in audit table model, as written by AuditLog
+type AuditModel struct {
+	Id            string      `json:"id" db:"id"`
+	TableName     string      `json:"tableName" db:"table_name" ddl:"index"`
+	LogRecords    interface{} `json:"logRecords" db:"log_records"`
+	NewLogRecords interface{} `json:"newLogRecords" db:"new_log_records"`
+	LogType       string      `json:"logType" db:"log_type" ddl:"index"`
+	LogBy         string      `json:"logBy" db:"log_by" ddl:"index"`
+	LogAt         time.Time   `json:"logAt" db:"log_at" ddl:"default:CURRENT_TIMESTAMP"`
+}
+
+// AccessModel is the built-in access (login-token) table model
+type AccessModel struct {
+	Id        string    `json:"id" db:"id"`
+	UserId    string    `json:"userId" db:"user_id" ddl:"index"`
+	LoginName string    `json:"loginName" db:"login_name"`
+	Token     string    `json:"token" db:"token" ddl:"index"`
+	Expire    int64     `json:"expire" db:"expire"` // expiry time, in milliseconds
+	CreatedAt time.Time `json:"createdAt" db:"created_at" ddl:"default:CURRENT_TIMESTAMP"`
+}
+
+// UserModel is the built-in user table model, for the access checks
+type UserModel struct {
+	BaseModelType
+	Username string `json:"username" db:"username" ddl:"unique"`
+	Email    string `json:"email" db:"email" ddl:"unique"`
+	Password string `json:"-" db:"password"`
+	IsAdmin  bool   `json:"isAdmin" db:"is_admin" ddl:"default:false"`
+}
+
+// RoleServiceModel is the built-in role-services (permissions) table model
+type RoleServiceModel struct {
+	BaseModelType
+	RoleId          string `json:"roleId" db:"role_id" ddl:"unique:role_service"`
+	ServiceId       string `json:"serviceId" db:"service_id" ddl:"unique:role_service"`
+	ServiceCategory string `json:"serviceCategory" db:"service_category"`
+	CanRead         bool   `json:"canRead" db:"can_read" ddl:"default:false"`
+	CanCreate       bool   `json:"canCreate" db:"can_create" ddl:"default:false"`
+	CanUpdate       bool   `json:"canUpdate" db:"can_update" ddl:"default:false"`
+	CanDelete       bool   `json:"canDelete" db:"can_delete" ddl:"default:false"`
+	CanCrud         bool   `json:"canCrud" db:"can_crud" ddl:"default:false"`
+}
+
+// UserRoleModel is the built-in user-roles table model
+type UserRoleModel struct {
+	BaseModelType
+	UserId string `json:"userId" db:"user_id" ddl:"index"`
+	RoleId string `json:"roleId" db:"role_id"`
+}
+
+// ServiceModel is the built-in services (tables, functions and other resources) table model
+type ServiceModel struct {
+	BaseModelType
+	Name     string `json:"name" db:"name" ddl:"unique"`
+	Category string `json:"category" db:"category"`
+}
+
+// CreateTable creates the table and indexes, from the model-struct, if not exists
+func CreateTable(appDb *sqlx.DB, modelRef interface{}, tableName string, dbType string) error {
+	if appDb == nil {
+		return errors.New("db-connection is required")
+	}
+	ddlQueryRes := ComputeCreateTableQuery(modelRef, tableName, dbType)
+	if !ddlQueryRes.Ok {
+		return errors.New(ddlQueryRes.Message)
+	}
+	tx, txErr := appDb.Beginx()
+	if txErr != nil {
+		return errors.New(fmt.Sprintf("error starting transaction: %v", txErr.Error()))
+	}
+	for _, script := range ddlQueryRes.CreateTableQueryObject.Scripts() {
+		if _, err := tx.Exec(script); err != nil {
+			_ = tx.Rollback()
+			return errors.New(fmt.Sprintf("error creating table[%v]: %v", tableName, err.Error()))
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return errors.New(fmt.Sprintf("error creating table[%v]: %v", tableName, err.Error()))
+	}
+	return nil
+}
+
+// CreateTableMigration returns the create-table (up) and drop-table (down) migration, from the model-struct
+func CreateTableMigration(version int64, name string, modelRef interface{}, tableName string, dbType string) (migrations.Migration, error) {
+	ddlQueryRes := ComputeCreateTableQuery(modelRef, tableName, dbType)
+	if !ddlQueryRes.Ok {
+		return migrations.Migration{}, errors.New(ddlQueryRes.Message)
+	}
+	scripts := ddlQueryRes.CreateTableQueryObject.Scripts()
+	return migrations.Migration{
+		Version: version,
+		Name:    name,
+		Up: func(tx *sqlx.Tx) error {
+			for _, script := range scripts {
+				if _, err := tx.Exec(script); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Down: func(tx *sqlx.Tx) error {
+			_, err := tx.Exec(ComputeDropTableQuery(tableName))
+			return err
+		},
+	}, nil
+}
+
+// BootstrapTables creates the built-in audit and access tables (audits, accesses, users, roles, user_roles,
+// role_parents, profiles and services, or as named by the crud-options), if not exists
+func BootstrapTables(appDb *sqlx.DB, dbType string, options CrudOptionsType) error {
+	tables := []struct {
+		modelRef  interface{}
+		tableName string
+		defName   string
+	}{
+		{AuditModel{}, options.AuditTable, "audits"},
+		{AccessModel{}, options.AccessTable, "accesses"},
+		{UserModel{}, options.UserTable, "users"},
+		{RoleServiceModel{}, options.RoleTable, "roles"},
+		{UserRoleModel{}, options.UserRoleTable, "user_roles"},
+		{RoleParentModel{}, options.RoleParentTable, "role_parents"},
+		{Profile{}, options.ProfileTable, "profiles"},
+		{ServiceModel{}, options.ServiceTable, "services"},
+	}
+	for _, table := range tables {
+		tableName := table.tableName
+		if tableName == "" {
+			tableName = table.defName
+		}
+		if err := CreateTable(appDb, table.modelRef, tableName, dbType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,12 +6,23 @@ package mcdbcrud
 
 import (
 	"fmt"
-	"github.com/abbeymart/mccache"
+	"strings"
+
 	"github.com/abbeymart/mcresponse"
 )
 
 // DeleteById method deletes or removes record(s) by record-id(s)
 func (crud *Crud) DeleteById(id string) mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(DeleteTask, nil, []string{id}); !ok {
+		return tenantRes
+	}
 	// current record(s)
 	getRes := crud.GetById(id)
 	if getRes.Code == "success" {
@@ -32,15 +43,58 @@ func (crud *Crud) DeleteById(id string) mcresponse.ResponseMessage {
 		})
 	}
 	//fmt.Printf("Delete-query: %v", deleteQueryRes.DeleteQueryObject.DeleteQuery )
-	res, delErr := crud.AppDb.Exec(deleteQueryRes.DeleteQueryObject.DeleteQuery, deleteQueryRes.DeleteQueryObject.FieldValues...)
+	// perform delete action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txErr.Error()),
+			Value:   nil,
+		})
+	}
+	// before-delete hooks: may veto the operation
+	if hookErr := crud.runHooks(BeforeDeleteHook, &HookParamsType{RecordIds: []string{id}, Records: crud.CurrentRecords, Tx: tx}); hookErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	// outbox change-event records, within the transaction, prior to the delete (by the delete where-condition)
+	deleteWhere := strings.TrimPrefix(deleteQueryRes.DeleteQueryObject.DeleteQuery, fmt.Sprintf("DELETE FROM %v ", crud.TableName))
+	outboxRecs, outboxIds, recsErr := crud.outboxRecords(tx, deleteWhere, deleteQueryRes.DeleteQueryObject.FieldValues...)
+	if recsErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", recsErr.Error()),
+			Value:   nil,
+		})
+	}
+	res, delErr := tx.Exec(deleteQueryRes.DeleteQueryObject.DeleteQuery, deleteQueryRes.DeleteQueryObject.FieldValues...)
 	if delErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
 			Value:   nil,
 		})
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, DeleteTask, outboxIds, outboxRecs); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
+	// commit
+	if txcErr := tx.Commit(); txcErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txcErr.Error()),
+			Value:   nil,
+		})
+	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -60,6 +114,8 @@ func (crud *Crud) DeleteById(id string) mcresponse.ResponseMessage {
 	if rcErr != nil {
 		rowsCount = 0
 	}
+	// after-delete hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterDeleteHook, &HookParamsType{RecordIds: []string{id}, Records: crud.CurrentRecords}, logMessage)
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) deleted successfully: [log-message: %v]", logMessage),
 		Value: CrudResultType{
@@ -73,6 +129,16 @@ func (crud *Crud) DeleteById(id string) mcresponse.ResponseMessage {
 
 // DeleteByIds method deletes or removes record(s) by record-id(s)
 func (crud *Crud) DeleteByIds() mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(DeleteTask, nil, crud.RecordIds); !ok {
+		return tenantRes
+	}
 	// current record(s)
 	getRes := crud.GetByIds()
 	if getRes.Code == "success" {
@@ -92,15 +158,58 @@ func (crud *Crud) DeleteByIds() mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
-	res, delErr := crud.AppDb.Exec(deleteQueryRes.DeleteQueryObject.DeleteQuery, deleteQueryRes.DeleteQueryObject.FieldValues...)
+	// perform delete action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txErr.Error()),
+			Value:   nil,
+		})
+	}
+	// before-delete hooks: may veto the operation
+	if hookErr := crud.runHooks(BeforeDeleteHook, &HookParamsType{RecordIds: crud.RecordIds, Records: crud.CurrentRecords, Tx: tx}); hookErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	// outbox change-event records, within the transaction, prior to the delete (by the delete where-condition)
+	deleteWhere := strings.TrimPrefix(deleteQueryRes.DeleteQueryObject.DeleteQuery, fmt.Sprintf("DELETE FROM %v ", crud.TableName))
+	outboxRecs, outboxIds, recsErr := crud.outboxRecords(tx, deleteWhere, deleteQueryRes.DeleteQueryObject.FieldValues...)
+	if recsErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", recsErr.Error()),
+			Value:   nil,
+		})
+	}
+	res, delErr := tx.Exec(deleteQueryRes.DeleteQueryObject.DeleteQuery, deleteQueryRes.DeleteQueryObject.FieldValues...)
 	if delErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
 			Value:   nil,
 		})
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, DeleteTask, outboxIds, outboxRecs); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
+	// commit
+	if txcErr := tx.Commit(); txcErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txcErr.Error()),
+			Value:   nil,
+		})
+	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -120,6 +229,8 @@ func (crud *Crud) DeleteByIds() mcresponse.ResponseMessage {
 	if rcErr != nil {
 		rowsCount = 0
 	}
+	// after-delete hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterDeleteHook, &HookParamsType{RecordIds: crud.RecordIds, Records: crud.CurrentRecords}, logMessage)
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) deleted successfully: [log-message: %v]", logMessage),
 		Value: CrudResultType{
@@ -133,6 +244,16 @@ func (crud *Crud) DeleteByIds() mcresponse.ResponseMessage {
 
 // DeleteByParam method deletes or removes record(s) by query-parameters or where conditions
 func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(DeleteTask, nil, nil); !ok {
+		return tenantRes
+	}
 	// current record(s)
 	getRes := crud.GetByParam()
 	if getRes.Code == "success" {
@@ -153,15 +274,58 @@ func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
-	res, delErr := crud.AppDb.Exec(deleteQueryRes.DeleteQueryObject.DeleteQuery, deleteQueryRes.DeleteQueryObject.FieldValues...)
+	// perform delete action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txErr.Error()),
+			Value:   nil,
+		})
+	}
+	// before-delete hooks: may veto the operation
+	if hookErr := crud.runHooks(BeforeDeleteHook, &HookParamsType{Records: crud.CurrentRecords, Tx: tx}); hookErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	// outbox change-event records, within the transaction, prior to the delete (by the delete where-condition)
+	deleteWhere := strings.TrimPrefix(deleteQueryRes.DeleteQueryObject.DeleteQuery, fmt.Sprintf("DELETE FROM %v ", crud.TableName))
+	outboxRecs, outboxIds, recsErr := crud.outboxRecords(tx, deleteWhere, deleteQueryRes.DeleteQueryObject.FieldValues...)
+	if recsErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", recsErr.Error()),
+			Value:   nil,
+		})
+	}
+	res, delErr := tx.Exec(deleteQueryRes.DeleteQueryObject.DeleteQuery, deleteQueryRes.DeleteQueryObject.FieldValues...)
 	if delErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
 			Value:   nil,
 		})
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, DeleteTask, outboxIds, outboxRecs); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
+	// commit
+	if txcErr := tx.Commit(); txcErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txcErr.Error()),
+			Value:   nil,
+		})
+	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -181,6 +345,8 @@ func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
 	if rcErr != nil {
 		rowsCount = 0
 	}
+	// after-delete hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterDeleteHook, &HookParamsType{Records: crud.CurrentRecords}, logMessage)
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) deleted successfully: [log-message: %v]", logMessage),
 		Value: CrudResultType{
@@ -195,19 +361,66 @@ func (crud *Crud) DeleteByParam() mcresponse.ResponseMessage {
 // DeleteAll method deletes or removes all records in the tables. Recommended for admin-users only
 // Use if and only if you know what you are doing
 func (crud *Crud) DeleteAll() mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(DeleteTask, nil, nil); !ok {
+		return tenantRes
+	}
+	// row-level app_id filter: the permitted records, by the app_id query-param
+	if crud.appIdFiltered() {
+		return crud.DeleteByParam()
+	}
 	// ***** perform DELETE-ALL-RECORDS FROM A TABLE, IF RELATIONS/CONSTRAINTS PERMIT *****
 	// ***** && IF-AND-ONLY-IF-YOU-KNOW-WHAT-YOU-ARE-DOING && AT-YOUR-OWN-RISK *****
 	// compute delete query
 	delQuery := fmt.Sprintf("DELETE FROM %v", crud.TableName)
-	res, delErr := crud.AppDb.Exec(delQuery)
+	// perform delete action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txErr.Error()),
+			Value:   nil,
+		})
+	}
+	// before-delete hooks: may veto the operation
+	if hookErr := crud.runHooks(BeforeDeleteHook, &HookParamsType{Records: crud.CurrentRecords, Tx: tx}); hookErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	res, delErr := tx.Exec(delQuery)
 	if delErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error deleting record(s): %v", delErr.Error()),
 			Value:   nil,
 		})
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, DeleteTask, nil, crud.CurrentRecords); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
+	// commit
+	if txcErr := tx.Commit(); txcErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("deleteError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error deleting record(s): %v", txcErr.Error()),
+			Value:   nil,
+		})
+	}
 	// delete cache, by key (TableName)
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -229,6 +442,8 @@ func (crud *Crud) DeleteAll() mcresponse.ResponseMessage {
 	if rcErr != nil {
 		rowsCount = 0
 	}
+	// after-delete hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterDeleteHook, &HookParamsType{Records: crud.CurrentRecords}, logMessage)
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) deleted successfully [log-message: %v] ", logMessage),
 		Value: CrudResultType{
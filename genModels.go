@@ -0,0 +1,280 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: generate go model-structs (and pointer variants) from the database tables
+
+package mcdbcrud
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// GenModelsOptionsType is the options for generating the model-structs
+type GenModelsOptionsType struct {
+	PackageName string   // default: models
+	TableNames  []string // default: all tables
+	Singular    bool     // singular struct-names, e.g. audits => Audit
+}
+
+const mcdbcrudImportPath = "github.com/abbeymart/mcdbcrud"
+
+// TableNames returns the table names, in alphabetical order, of the current database/schema
+func TableNames(appDb *sqlx.DB, dbType string) ([]string, error) {
+	if appDb == nil {
+		return nil, errors.New("db-connection is required")
+	}
+	var tableScript string
+	switch dbType {
+	case "postgres":
+		tableScript = "SELECT table_name FROM information_schema.tables WHERE table_schema = current_schema() AND table_type = 'BASE TABLE' ORDER BY table_name"
+	case "mysql", "mariadb":
+		tableScript = "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name"
+	case "sqlite3":
+		tableScript = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown db-type(%v)", dbType))
+	}
+	var tableNames []string
+	if err := appDb.Select(&tableNames, tableScript); err != nil {
+		return nil, errors.New(fmt.Sprintf("error reading table names: %v", err.Error()))
+	}
+	return tableNames, nil
+}
+
+// genPascalCase converts the underscore/space/dash separated name to PascalCase, e.g. log_records => LogRecords
+func genPascalCase(name string) string {
+	var words []string
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		// the first rune (not byte) upper-cased, e.g. for the non-ASCII column-names
+		firstRune, size := utf8.DecodeRuneInString(word)
+		words = append(words, string(unicode.ToUpper(firstRune))+strings.ToLower(word[size:]))
+	}
+	pascalName := strings.Join(words, "")
+	if firstRune, _ := utf8.DecodeRuneInString(pascalName); pascalName == "" || unicode.IsDigit(firstRune) {
+		pascalName = "X" + pascalName
+	}
+	return pascalName
+}
+
+// genCamelCase converts the underscore separated name to camelCase, e.g. log_records => logRecords
+func genCamelCase(name string) string {
+	pascalName := genPascalCase(name)
+	firstRune, size := utf8.DecodeRuneInString(pascalName)
+	return string(unicode.ToLower(firstRune)) + pascalName[size:]
+}
+
+// genSingular returns the naive singular-name, e.g. audits => audit, accesses => access, categories => category
+func genSingular(name string) string {
+	lowerName := strings.ToLower(name)
+	switch {
+	case strings.HasSuffix(lowerName, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(lowerName, "sses"), strings.HasSuffix(lowerName, "xes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(lowerName, "s") && !strings.HasSuffix(lowerName, "ss"):
+		return name[:len(name)-1]
+	default:
+		return name
+	}
+}
+
+// genGoType returns the go-type for the table-column data-type
+func genGoType(dataType string) string {
+	lowerType := strings.ToLower(dataType)
+	switch columnTypeFamily(dataType) {
+	case "boolean":
+		return "bool"
+	case "integer":
+		if strings.Contains(lowerType, "big") || strings.Contains(lowerType, "int8") {
+			return "int64"
+		}
+		return "int"
+	case "numeric", "float":
+		return "float64"
+	case "timestamp":
+		return "time.Time"
+	case "json":
+		return "interface{}"
+	case "binary":
+		return "[]byte"
+	default:
+		if strings.HasPrefix(lowerType, "date") || strings.HasPrefix(lowerType, "time") {
+			return "time.Time"
+		}
+		return "string"
+	}
+}
+
+// genBaseModel returns the embeddable base-model (AppBaseModelType or BaseModelType) whose columns
+// (names and types) match the table-columns, and the matched column-names
+func genBaseModel(columns []TableColumnType, dbType string) (string, map[string]bool) {
+	columnMap := map[string]TableColumnType{}
+	for _, column := range columns {
+		columnMap[column.ColumnName] = column
+	}
+	baseModels := []struct {
+		name     string
+		modelRef interface{}
+	}{
+		{"AppBaseModelType", AppBaseModelType{}},
+		{"BaseModelType", BaseModelType{}},
+	}
+	for _, baseModel := range baseModels {
+		baseColumns, err := ModelColumns(baseModel.modelRef, dbType)
+		if err != nil {
+			continue
+		}
+		matched := map[string]bool{}
+		for _, baseColumn := range baseColumns {
+			column, ok := columnMap[baseColumn.ColumnName]
+			if !ok {
+				break
+			}
+			baseType := baseColumn.ColumnType
+			if baseColumn.PrimaryKey {
+				baseType, _ = ddlPrimaryKeyType(baseColumn.GoKind, dbType)
+			}
+			if columnTypeFamily(baseType) != columnTypeFamily(column.DataType) {
+				break
+			}
+			matched[baseColumn.ColumnName] = true
+		}
+		if len(matched) == len(baseColumns) {
+			return baseModel.name, matched
+		}
+	}
+	return "", nil
+}
+
+// GenerateModels generates the gofmt'd go source of the model-structs, with json/db tags, for the database tables.
+// Each table generates the TableName constant, the model-struct and, for nullable columns, the pointer variant
+// (e.g. AuditTable, Audit and AuditPtr). Base-model types are embedded where the columns match.
+// Output is deterministic: tables by name and columns by ordinal position
+func GenerateModels(appDb *sqlx.DB, dbType string, options GenModelsOptionsType) ([]byte, error) {
+	packageName := options.PackageName
+	if packageName == "" {
+		packageName = "models"
+	}
+	tableNames := options.TableNames
+	if len(tableNames) < 1 {
+		var err error
+		if tableNames, err = TableNames(appDb, dbType); err != nil {
+			return nil, err
+		}
+	}
+	tableNames = append([]string{}, tableNames...)
+	sort.Strings(tableNames)
+	if len(tableNames) < 1 {
+		return nil, errors.New("no tables found")
+	}
+	// base-model qualifier, for packages other than mcdbcrud
+	qualifier := ""
+	if packageName != "mcdbcrud" {
+		qualifier = "mcdbcrud."
+	}
+	var (
+		body     bytes.Buffer
+		usesTime bool
+		usesCrud bool
+	)
+	structNames := map[string]string{}  // by table-name
+	tableStructs := map[string]string{} // by struct-name
+	for _, tableName := range tableNames {
+		structName := tableName
+		if options.Singular {
+			structName = genSingular(tableName)
+		}
+		structName = genPascalCase(structName)
+		if prevTable, ok := tableStructs[structName]; ok {
+			return nil, errors.New(fmt.Sprintf("duplicate struct-name(%v) for tables %v and %v", structName, prevTable, tableName))
+		}
+		tableStructs[structName] = tableName
+		structNames[tableName] = structName
+	}
+	for _, tableName := range tableNames {
+		columns, err := TableColumns(appDb, tableName, dbType)
+		if err != nil {
+			return nil, err
+		}
+		structName := structNames[tableName]
+
+		baseModel, baseColumns := genBaseModel(columns, dbType)
+		hasNullable := false
+		for _, column := range columns {
+			goType := genGoType(column.DataType)
+			if !baseColumns[column.ColumnName] && column.Nullable && goType != "interface{}" && goType != "[]byte" {
+				hasNullable = true
+			}
+		}
+		variants := []bool{false}
+		if hasNullable || baseModel != "" {
+			variants = append(variants, true)
+		}
+		for _, ptrVariant := range variants {
+			typeName := structName
+			if ptrVariant {
+				typeName += "Ptr"
+			}
+			_, _ = fmt.Fprintf(&body, "\ntype %v struct {\n", typeName)
+			if baseModel != "" {
+				baseName := baseModel
+				if ptrVariant {
+					baseName = strings.TrimSuffix(baseModel, "Type") + "PtrType"
+				}
+				_, _ = fmt.Fprintf(&body, "\t%v%v\n", qualifier, baseName)
+				if qualifier != "" {
+					usesCrud = true
+				}
+			}
+			for _, column := range columns {
+				if baseColumns[column.ColumnName] {
+					continue
+				}
+				goType := genGoType(column.DataType)
+				if ptrVariant && column.Nullable && goType != "interface{}" && goType != "[]byte" {
+					goType = "*" + goType
+				}
+				if strings.Contains(goType, "time.Time") {
+					usesTime = true
+				}
+				_, _ = fmt.Fprintf(&body, "\t%v %v `json:\"%v\" db:\"%v\"`\n", genPascalCase(column.ColumnName), goType,
+					genCamelCase(column.ColumnName), column.ColumnName)
+			}
+			body.WriteString("}\n")
+		}
+	}
+	// source: package, imports, table-name constants and model-structs
+	var source bytes.Buffer
+	_, _ = fmt.Fprintf(&source, "// Code generated by mcdbcrud-gen. DO NOT EDIT.\n\npackage %v\n\n", packageName)
+	if usesTime || usesCrud {
+		source.WriteString("import (\n")
+		if usesTime {
+			source.WriteString("\t\"time\"\n")
+		}
+		if usesCrud {
+			_, _ = fmt.Fprintf(&source, "\n\t\"%v\"\n", mcdbcrudImportPath)
+		}
+		source.WriteString(")\n\n")
+	}
+	source.WriteString("const (\n")
+	for _, tableName := range tableNames {
+		_, _ = fmt.Fprintf(&source, "\t%vTable = %q\n", structNames[tableName], tableName)
+	}
+	source.WriteString(")\n")
+	source.Write(body.Bytes())
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("error formatting generated models: %v", err.Error()))
+	}
+	return formatted, nil
+}
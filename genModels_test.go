@@ -0,0 +1,61 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: generate model-structs test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestGenerateModels(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "gen.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	_ = BootstrapTables(sqliteDb, "sqlite3", CrudOptionsType{})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should list the table names, in alphabetical order:",
+		TestFunc: func() {
+			tableNames, err := TableNames(sqliteDb, "sqlite3")
+			mctest.AssertEquals(t, err, nil, "table-names error should be: nil")
+			mctest.AssertEquals(t, strings.Join(tableNames, ","), "accesses,audits,profiles,role_parents,roles,services,user_roles,users", "table-names should be: bootstrap tables")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should generate deterministic model-structs, with pointer variants and base-model embedding:",
+		TestFunc: func() {
+			options := GenModelsOptionsType{PackageName: "models", TableNames: []string{"users", "audits"}, Singular: true}
+			source, err := GenerateModels(sqliteDb, "sqlite3", options)
+			mctest.AssertEquals(t, err, nil, "generate error should be: nil")
+			repeatSource, _ := GenerateModels(sqliteDb, "sqlite3", options)
+			mctest.AssertEquals(t, string(source), string(repeatSource), "generated source should be: deterministic")
+			code := string(source)
+			mctest.AssertEquals(t, strings.Contains(code, "AuditTable = \"audits\""), true, "source should include: AuditTable constant")
+			mctest.AssertEquals(t, strings.Contains(code, "LogRecords    string    `json:\"logRecords\" db:\"log_records\"`"), true, "source should include: LogRecords field")
+			mctest.AssertEquals(t, strings.Contains(code, "type User struct {\n\tmcdbcrud.BaseModelType\n"), true, "source should include: embedded BaseModelType")
+			mctest.AssertEquals(t, strings.Contains(code, "type UserPtr struct {\n\tmcdbcrud.BaseModelPtrType\n"), true, "source should include: embedded BaseModelPtrType")
+			mctest.AssertEquals(t, strings.Contains(code, "LogRecords    *string   `json:\"logRecords\" db:\"log_records\"`"), true, "source should include: nullable AuditPtr LogRecords field")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should convert names for the generated structs and fields:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, genSingular("accesses"), "access", "singular name should be: access")
+			mctest.AssertEquals(t, genSingular("categories"), "category", "singular name should be: category")
+			mctest.AssertEquals(t, genPascalCase("user_roles"), "UserRoles", "pascal-case name should be: UserRoles")
+			mctest.AssertEquals(t, genCamelCase("new_log_records"), "newLogRecords", "camel-case name should be: newLogRecords")
+			mctest.AssertEquals(t, genPascalCase("état_civil"), "ÉtatCivil", "non-ASCII pascal-case name should be: ÉtatCivil")
+			mctest.AssertEquals(t, genCamelCase("über_name"), "überName", "non-ASCII camel-case name should be: überName")
+		},
+	})
+
+	mctest.PostTestResult()
+}
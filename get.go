@@ -9,7 +9,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mcresponse"
 	"github.com/jmoiron/sqlx"
 )
@@ -18,16 +17,21 @@ import (
 // constrained by optional skip and limit
 
 func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, []string{id}); !ok {
+		return tenantRes
+	}
 	// check cache
+	cacheKey := crud.readCacheKey(CacheReadById, []string{id})
 	if crud.CacheResult {
-		getCacheRes := mccache.GetHashCache(crud.CacheKey, crud.TableName)
-		val, ok := getCacheRes.Value.(GetResultType)
-		if getCacheRes.Ok && ok && len(val.Records) > 0 {
-			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-				Message: "records successfully retrieved from the cache",
-				Value:   val,
-			})
-		}
+		// cached, coalesced (single-flight) or stale-while-revalidate read
+		return crud.cachedRead(cacheKey, func(readCrud *Crud) mcresponse.ResponseMessage {
+			return readCrud.GetById(id)
+		})
 	}
 	logMessage := ""
 	selectOptions := SelectQueryOptions{
@@ -43,10 +47,17 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 	}
 	//fmt.Printf("Get-query-by-id: %v \n", getQueryRes.SelectQueryObject.SelectQuery )
 	//fmt.Printf("Get-by-id-values: %#v\n", getQueryRes.SelectQueryObject.FieldValues)
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -54,7 +65,7 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 		})
 	}
 	// perform crud-task action
-	row := crud.AppDb.QueryRowx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	row := readDb.QueryRowx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	//fmt.Printf("get-by-id-row: %#v \n", row)
 	// check rows count
 	//var rowCount = 0
@@ -94,6 +105,11 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 
 	//rowCount += len(getRecords)
 	// perform audit-log
@@ -127,7 +143,7 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, int64(crud.CacheExpire))
+	crud.setCache(cacheKey, getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -138,16 +154,21 @@ func (crud *Crud) GetById(id string) mcresponse.ResponseMessage {
 // GetByIds method fetches/gets/reads records that met the specified record-ids,
 // constrained by optional skip and limit parameters
 func (crud *Crud) GetByIds() mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, crud.RecordIds); !ok {
+		return tenantRes
+	}
 	// check cache
+	cacheKey := crud.readCacheKey(CacheReadByIds, crud.RecordIds)
 	if crud.CacheResult {
-		getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-		val, ok := getCacheRes.Value.(GetResultType)
-		if getCacheRes.Ok && ok && len(val.Records) > 0 {
-			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-				Message: "records successfully retrieved from the cache",
-				Value:   val,
-			})
-		}
+		// cached, coalesced (single-flight) or stale-while-revalidate read
+		return crud.cachedRead(cacheKey, func(readCrud *Crud) mcresponse.ResponseMessage {
+			return readCrud.GetByIds()
+		})
 	}
 	if len(crud.RecordIds) < 1 {
 		return mcresponse.GetResMessage("paramsError",
@@ -169,10 +190,17 @@ func (crud *Crud) GetByIds() mcresponse.ResponseMessage {
 		})
 	}
 	//fmt.Printf("Get-query-by-ids: %#v \n", getQueryRes )
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -180,7 +208,7 @@ func (crud *Crud) GetByIds() mcresponse.ResponseMessage {
 		})
 	}
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	rows, qRowErr := readDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	//fmt.Printf("rows-result: %v \n", rows)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
@@ -246,6 +274,11 @@ func (crud *Crud) GetByIds() mcresponse.ResponseMessage {
 			},
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 	// perform audit-log
 	logRes := mcresponse.ResponseMessage{}
 	var logErr error
@@ -277,7 +310,7 @@ func (crud *Crud) GetByIds() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, int64(crud.CacheExpire))
+	crud.setCache(cacheKey, getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -288,16 +321,21 @@ func (crud *Crud) GetByIds() mcresponse.ResponseMessage {
 // GetByParam method fetches/gets/reads records that met the specified query-params or where conditions,
 // constrained by optional skip and limit parameters
 func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, nil); !ok {
+		return tenantRes
+	}
 	// check cache
+	cacheKey := crud.readCacheKey(CacheReadByParam, nil)
 	if crud.CacheResult {
-		getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-		val, ok := getCacheRes.Value.(GetResultType)
-		if getCacheRes.Ok && ok && len(val.Records) > 0 {
-			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-				Message: "records successfully retrieved from the cache",
-				Value:   val,
-			})
-		}
+		// cached, coalesced (single-flight) or stale-while-revalidate read
+		return crud.cachedRead(cacheKey, func(readCrud *Crud) mcresponse.ResponseMessage {
+			return readCrud.GetByParam()
+		})
 	}
 	logMessage := ""
 	selectOptions := SelectQueryOptions{
@@ -312,10 +350,17 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 		})
 	}
 	//fmt.Printf("\n Get-query-by-params: %#v \n\n", getQueryRes)
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -323,7 +368,7 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 		})
 	}
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	rows, qRowErr := readDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -386,6 +431,11 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 			},
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 	// perform audit-log
 	logRes := mcresponse.ResponseMessage{}
 	var logErr error
@@ -417,7 +467,7 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, int64(crud.CacheExpire))
+	crud.setCache(cacheKey, getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) read-query completed successfully [log-message: %v]", logMessage),
@@ -427,6 +477,18 @@ func (crud *Crud) GetByParam() mcresponse.ResponseMessage {
 
 // GetAll method fetches/gets/reads all record(s), constrained by optional skip and limit parameters
 func (crud *Crud) GetAll() mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, nil); !ok {
+		return tenantRes
+	}
+	// row-level app_id filter: the permitted records, by the app_id query-param
+	if crud.appIdFiltered() {
+		return crud.GetByParam()
+	}
 	// compute select-query
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
@@ -439,10 +501,17 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error[scan-total-records-count]: %v", tRowErr.Error()),
@@ -450,7 +519,7 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 		})
 	}
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	rows, qRowErr := readDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -514,6 +583,11 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 			},
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 	// perform audit-log | initialize log-variables
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -546,7 +620,7 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache | *****don't cache all-table-records, due to large/unknown size*****
-	//_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getRecords, uint(crud.CacheExpire))
+	//crud.setCache(getRecords)
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) read-query completed successfully [log-message: %v]", logMessage),
@@ -556,6 +630,18 @@ func (crud *Crud) GetAll() mcresponse.ResponseMessage {
 
 // CustomSelectQuery method obtain the query result for the specified selectQuery, tableName and modelPointer and optional fieldPositionalValues.
 func (crud *Crud) CustomSelectQuery(params CustomSelectQueryParamsType) mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, nil); !ok {
+		return tenantRes
+	}
+	// row-level app_id filter: the custom select-query cannot be scoped by the permitted app_id
+	if crud.appIdFiltered() {
+		return appIdErrMessage("custom select-query is not permitted, with the app_id filter")
+	}
 	//  validate required parameters
 	if params.SelectQuery == "" || params.TableName == "" || params.ModelPointer == nil {
 		return mcresponse.ResponseMessage{
@@ -564,9 +650,15 @@ func (crud *Crud) CustomSelectQuery(params CustomSelectQueryParamsType) mcrespon
 			Value:   nil,
 		}
 	}
-	// totalRecordsCount, for the query-condition, from the table
+	// totalRecordsCount, for the query-condition, from the table | or the specified countQuery (e.g. joins)
 	var totalRows int
+	readDb := crud.readDb()
 	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", params.TableName)
+	var countFieldValues []interface{}
+	if params.CountQuery != "" {
+		countQuery = params.CountQuery
+		countFieldValues = params.QueryPositionalFieldValues
+	}
 	// adjust selectQuery for skip and limit options
 	if !strings.Contains(params.SelectQuery, "LIMIT") && params.CrudParams.Limit > 0 {
 		params.SelectQuery += fmt.Sprintf(" LIMIT %v", params.CrudParams.Limit)
@@ -575,7 +667,7 @@ func (crud *Crud) CustomSelectQuery(params CustomSelectQueryParamsType) mcrespon
 		params.SelectQuery += fmt.Sprintf(" OFFSET %v", params.CrudParams.Skip)
 	}
 	// Perform query
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error[scan-total-records-count]: %v", tRowErr.Error()),
@@ -583,7 +675,7 @@ func (crud *Crud) CustomSelectQuery(params CustomSelectQueryParamsType) mcrespon
 		})
 	}
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Queryx(params.SelectQuery, params.QueryPositionalFieldValues...)
+	rows, qRowErr := readDb.Queryx(params.SelectQuery, params.QueryPositionalFieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -646,6 +738,11 @@ func (crud *Crud) CustomSelectQuery(params CustomSelectQueryParamsType) mcrespon
 			},
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 	// result
 	limit := params.CrudParams.Limit
 	skip := params.CrudParams.Skip
@@ -677,16 +774,21 @@ func (crud *Crud) CustomSelectQuery(params CustomSelectQueryParamsType) mcrespon
 // get-scan-to-map
 
 func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, []string{id}); !ok {
+		return tenantRes
+	}
 	// check cache
+	cacheKey := crud.readCacheKey(CacheReadById, []string{id})
 	if crud.CacheResult {
-		getCacheRes := mccache.GetHashCache(crud.CacheKey, crud.TableName)
-		val, ok := getCacheRes.Value.(GetResultType)
-		if getCacheRes.Ok && ok && len(val.Records) > 0 {
-			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-				Message: "records successfully retrieved from the cache",
-				Value:   val,
-			})
-		}
+		// cached, coalesced (single-flight) or stale-while-revalidate read
+		return crud.cachedRead(cacheKey, func(readCrud *Crud) mcresponse.ResponseMessage {
+			return readCrud.GetById1(id)
+		})
 	}
 	logMessage := ""
 	selectOptions := SelectQueryOptions{
@@ -701,10 +803,17 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 		})
 	}
 	//fmt.Printf("Get-query-by-id: %v \n", getQueryRes.SelectQueryObject.SelectQuery )
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -714,7 +823,7 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 	// perform crud-task action
 
 	mapRes := make(map[string]interface{})
-	row := crud.AppDb.QueryRowx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	row := readDb.QueryRowx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	//fmt.Printf("get-by-id-row: %v \n", row)
 	qRowErr := row.MapScan(mapRes)
 	if qRowErr != nil {
@@ -763,6 +872,11 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 
 	//rowCount += len(getRecords)
 	// perform audit-log
@@ -796,7 +910,7 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, int64(crud.CacheExpire))
+	crud.setCache(cacheKey, getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) read-query completed successfully [log-message: %v]", logMessage),
@@ -805,16 +919,21 @@ func (crud *Crud) GetById1(id string) mcresponse.ResponseMessage {
 }
 
 func (crud *Crud) GetByIds1() mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, crud.RecordIds); !ok {
+		return tenantRes
+	}
 	// check cache
+	cacheKey := crud.readCacheKey(CacheReadByIds, crud.RecordIds)
 	if crud.CacheResult {
-		getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-		val, ok := getCacheRes.Value.(GetResultType)
-		if getCacheRes.Ok && ok && len(val.Records) > 0 {
-			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-				Message: "records successfully retrieved from the cache",
-				Value:   val,
-			})
-		}
+		// cached, coalesced (single-flight) or stale-while-revalidate read
+		return crud.cachedRead(cacheKey, func(readCrud *Crud) mcresponse.ResponseMessage {
+			return readCrud.GetByIds1()
+		})
 	}
 	if len(crud.RecordIds) < 1 {
 		return mcresponse.GetResMessage("paramsError",
@@ -836,10 +955,17 @@ func (crud *Crud) GetByIds1() mcresponse.ResponseMessage {
 		})
 	}
 	//fmt.Printf("Get-query-by-ids: %#v \n", getQueryRes )
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -847,7 +973,7 @@ func (crud *Crud) GetByIds1() mcresponse.ResponseMessage {
 		})
 	}
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	rows, qRowErr := readDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	//fmt.Printf("rows-result: %v \n", rows)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
@@ -923,6 +1049,11 @@ func (crud *Crud) GetByIds1() mcresponse.ResponseMessage {
 			},
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 	// perform audit-log
 	logRes := mcresponse.ResponseMessage{}
 	var logErr error
@@ -954,7 +1085,7 @@ func (crud *Crud) GetByIds1() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, int64(crud.CacheExpire))
+	crud.setCache(cacheKey, getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) read-query completed successfully [log-message: %v]", logMessage),
@@ -965,16 +1096,21 @@ func (crud *Crud) GetByIds1() mcresponse.ResponseMessage {
 // GetByParam1 method fetches/gets/reads records that met the specified query-params or where conditions,
 // constrained by optional skip and limit parameters
 func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, nil); !ok {
+		return tenantRes
+	}
 	// check cache
+	cacheKey := crud.readCacheKey(CacheReadByParam, nil)
 	if crud.CacheResult {
-		getCacheRes := mccache.GetHashCache(crud.TableName, crud.CacheKey)
-		val, ok := getCacheRes.Value.(GetResultType)
-		if getCacheRes.Ok && ok && len(val.Records) > 0 {
-			return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
-				Message: "records successfully retrieved from the cache",
-				Value:   val,
-			})
-		}
+		// cached, coalesced (single-flight) or stale-while-revalidate read
+		return crud.cachedRead(cacheKey, func(readCrud *Crud) mcresponse.ResponseMessage {
+			return readCrud.GetByParam1()
+		})
 	}
 	logMessage := ""
 	selectOptions := SelectQueryOptions{
@@ -989,10 +1125,17 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 		})
 	}
 	//fmt.Printf("Get-query-by-params: %#v \n\n", getQueryRes )
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -1000,7 +1143,7 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 		})
 	}
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	rows, qRowErr := readDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -1073,6 +1216,11 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 			},
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 	// perform audit-log
 	logRes := mcresponse.ResponseMessage{}
 	var logErr error
@@ -1104,7 +1252,7 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache
-	_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getResult, int64(crud.CacheExpire))
+	crud.setCache(cacheKey, getResult)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: logMessage,
@@ -1114,6 +1262,18 @@ func (crud *Crud) GetByParam1() mcresponse.ResponseMessage {
 
 // GetAll1 method fetches/gets/reads all record(s), constrained by optional skip and limit parameters
 func (crud *Crud) GetAll1() mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, nil); !ok {
+		return tenantRes
+	}
+	// row-level app_id filter: the permitted records, by the app_id query-param
+	if crud.appIdFiltered() {
+		return crud.GetByParam1()
+	}
 	// compute select-query
 	selectOptions := SelectQueryOptions{
 		Skip:  crud.Skip,
@@ -1127,10 +1287,17 @@ func (crud *Crud) GetAll1() mcresponse.ResponseMessage {
 		})
 	}
 	//fmt.Printf("Get-query-by-all: %#v", getQueryRes )
-	// totalRecordsCount from the table
+	// totalRecordsCount from the table, scoped by the app_id filter
 	var totalRows int
-	countQuery := fmt.Sprintf("SELECT COUNT(*) AS total_rows FROM %v", crud.TableName)
-	tRowErr := crud.AppDb.QueryRowx(countQuery).Scan(&totalRows)
+	readDb := crud.readDb()
+	countQuery, countFieldValues, countErr := crud.recordsCountQuery("total_rows")
+	if countErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", countErr.Error()),
+			Value:   nil,
+		})
+	}
+	tRowErr := readDb.QueryRowx(countQuery, countFieldValues...).Scan(&totalRows)
 	if tRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", tRowErr.Error()),
@@ -1138,7 +1305,7 @@ func (crud *Crud) GetAll1() mcresponse.ResponseMessage {
 		})
 	}
 	// perform crud-task action
-	rows, qRowErr := crud.AppDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	rows, qRowErr := readDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
 	if qRowErr != nil {
 		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
@@ -1210,6 +1377,11 @@ func (crud *Crud) GetAll1() mcresponse.ResponseMessage {
 			},
 		})
 	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
 	// perform audit-log | initialize log-variables
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -1242,7 +1414,7 @@ func (crud *Crud) GetAll1() mcresponse.ResponseMessage {
 		LogRes:   logRes,
 	}
 	// update cache | *****don't cache all-table-records, due to large/unknown size*****
-	//_ = mccache.SetHashCache(crud.CacheKey, crud.TableName, getRecords, uint(crud.CacheExpire))
+	//crud.setCache(getRecords)
 
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) read-query completed successfully [log-message: %v]", logMessage),
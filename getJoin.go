@@ -0,0 +1,199 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: get / query record(s) from joined tables
+
+package mcdbcrud
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+)
+
+// nestedMapRecord transforms the map-scanned row into nested map-record, by table-alias qualifier,
+// e.g. {"u.login_name": "abc"} => {"u": {"loginName": "abc"}}
+func nestedMapRecord(mapRes map[string]interface{}, sep string) map[string]interface{} {
+	mapValue := map[string]interface{}{}
+	for key, val := range mapRes {
+		if bVal, ok := val.([]byte); ok {
+			val = string(bVal)
+		}
+		keyParts := strings.SplitN(key, ".", 2)
+		if len(keyParts) < 2 || keyParts[0] == "" || keyParts[1] == "" {
+			mapValue[ToCamelCase(key, sep)] = val
+			continue
+		}
+		subMap, ok := mapValue[keyParts[0]].(map[string]interface{})
+		if !ok {
+			subMap = map[string]interface{}{}
+			mapValue[keyParts[0]] = subMap
+		}
+		subMap[ToCamelCase(keyParts[1], sep)] = val
+	}
+	return mapValue
+}
+
+// GetJoin method fetches/gets/reads records from the base-table and the joined tables,
+// constrained by optional (qualified) where-conditions, sort, skip and limit parameters.
+// Records are scanned into params.ModelPointer, if specified, otherwise into nested maps by table-alias
+func (crud *Crud) GetJoin(params JoinSelectQueryParamsType) mcresponse.ResponseMessage {
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(ReadTask, nil, nil); !ok {
+		return tenantRes
+	}
+	// row-level app_id filter: the joined tables cannot be scoped by the permitted app_id
+	if crud.appIdFiltered() {
+		return appIdErrMessage("join select-query is not permitted, with the app_id filter")
+	}
+	// tenant table-prefix or schema: the base and joined tables are qualified, as the crud-instance table
+	if qualifyErr := crud.qualifyJoinTables(&params); qualifyErr != nil {
+		return tenantErrMessage(qualifyErr.Error())
+	}
+	if params.Limit <= 0 || params.Limit > crud.MaxQueryLimit {
+		params.Limit = crud.MaxQueryLimit
+	}
+	if params.Skip < 0 {
+		params.Skip = 0
+	}
+	getQueryRes := ComputeSelectQueryJoin(params)
+	if !getQueryRes.Ok {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: getQueryRes.Message,
+			Value:   nil,
+		})
+	}
+	countQueryRes := ComputeCountQueryJoin(params)
+	if !countQueryRes.Ok {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: countQueryRes.Message,
+			Value:   nil,
+		})
+	}
+	// totalRecordsCount, for the joins and where-conditions
+	var totalRows int
+	readDb := crud.readDb()
+	tRowErr := readDb.QueryRowx(countQueryRes.SelectQueryObject.SelectQuery, countQueryRes.SelectQueryObject.FieldValues...).Scan(&totalRows)
+	if tRowErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error[scan-total-records-count]: %v", tRowErr.Error()),
+			Value:   nil,
+		})
+	}
+	// perform crud-task action
+	rows, qRowErr := readDb.Queryx(getQueryRes.SelectQueryObject.SelectQuery, getQueryRes.SelectQueryObject.FieldValues...)
+	if qRowErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Db query Error: %v", qRowErr.Error()),
+			Value:   nil,
+		})
+	}
+	defer func(rows *sqlx.Rows) {
+		err := rows.Close()
+		if err != nil {
+
+		}
+	}(rows)
+	var getRecords []map[string]interface{}
+	for rows.Next() {
+		if params.ModelPointer == nil {
+			mapRes := map[string]interface{}{}
+			if rowScanErr := rows.MapScan(mapRes); rowScanErr != nil {
+				return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+					Message: fmt.Sprintf("Error reading/getting records[row-scan-map]: %v", rowScanErr.Error()),
+					Value:   nil,
+				})
+			}
+			getRecords = append(getRecords, nestedMapRecord(mapRes, crud.FieldSeparator))
+			continue
+		}
+		// cast model as struct
+		scanRowErr := rows.StructScan(params.ModelPointer)
+		if scanRowErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading/getting records[row-scan]: %v", scanRowErr.Error()),
+				Value:   nil,
+			})
+		}
+		// transform snapshot value from model-struct to map-value
+		jByte, jErr := json.Marshal(params.ModelPointer)
+		if jErr != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
+				Value:   nil,
+			})
+		}
+		mapValue := map[string]interface{}{}
+		jErr = json.Unmarshal(jByte, &mapValue)
+		if jErr != nil {
+			return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error transforming result-value into json-value-format: %v", jErr.Error()),
+				Value:   nil,
+			})
+		}
+		getRecords = append(getRecords, mapValue)
+	}
+	// handles not-found-error
+	if len(getRecords) < 1 {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "RECORDS NOT FOUND.",
+			Value:   nil,
+		})
+	}
+	if rowErr := rows.Err(); rowErr != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading/getting records: %v", rowErr.Error()),
+			Value: GetResultType{
+				Records:  nil,
+				Stats:    GetStatType{},
+				TaskType: crud.TaskType,
+				LogRes:   mcresponse.ResponseMessage{},
+			},
+		})
+	}
+	// after-read hooks: may mutate the records, or fail the read operation
+	hookRes, ok := crud.runReadHooks(&getRecords)
+	if !ok {
+		return hookRes
+	}
+	// perform audit-log
+	logMessage := ""
+	logRes := mcresponse.ResponseMessage{}
+	var logErr error
+	if crud.LogRead || crud.LogCrud {
+		logRecs := map[string]interface{}{"joins": params.Joins, "queryParams": params.QueryParams}
+		auditInfo := AuditLogOptionsType{
+			TableName:  params.TableName,
+			LogRecords: LogRecordsType{LogRecords: logRecs},
+		}
+		if logRes, logErr = crud.TransLog.AuditLog(ReadTask, crud.UserInfo.UserId, auditInfo); logErr != nil {
+			logMessage = fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+		} else {
+			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+		}
+	}
+	// result
+	getResult := GetResultType{
+		Records: getRecords,
+		Stats: GetStatType{
+			Skip:              params.Skip,
+			Limit:             params.Limit,
+			RecordsCount:      len(getRecords),
+			TotalRecordsCount: totalRows,
+			QueryParam:        params.QueryParams,
+			RecordIds:         []string{},
+		},
+		TaskType: ReadTask,
+		LogRes:   logRes,
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Record(s) read-query completed successfully [log-message: %v]", logMessage),
+		Value:   getResult,
+	})
+}
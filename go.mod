@@ -7,7 +7,10 @@ require (
 	github.com/abbeymart/mcresponse v0.9.6
 	github.com/abbeymart/mctest v0.6.0
 	github.com/asaskevich/govalidator v0.0.0-20230301143203-a9d515a09cc2
+	github.com/go-sql-driver/mysql v1.8.1
 	github.com/jmoiron/sqlx v1.4.0
 	github.com/lib/pq v1.10.9
 	github.com/mattn/go-sqlite3 v1.14.32
 )
+
+require filippo.io/edwards25519 v1.1.0 // indirect
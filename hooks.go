@@ -0,0 +1,153 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: lifecycle hooks (before/after create, update, delete and after read), by crud-instance or table
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+)
+
+// lifecycle hook types
+const (
+	BeforeCreateHook = "beforeCreate"
+	AfterCreateHook  = "afterCreate"
+	BeforeUpdateHook = "beforeUpdate"
+	AfterUpdateHook  = "afterUpdate"
+	BeforeDeleteHook = "beforeDelete"
+	AfterDeleteHook  = "afterDelete"
+	AfterReadHook    = "afterRead"
+)
+
+// HookParamsType is the hook function parameter. Before-hooks may mutate the ActionParams (create/update records)
+// and use the active transaction (Tx). After-hooks run once the transaction has committed (Tx is nil),
+// and may mutate the Records (after-read)
+type HookParamsType struct {
+	TableName    string
+	TaskType     string
+	UserInfo     UserInfoType
+	ActionParams ActionParamsType
+	QueryParams  QueryParamType
+	RecordIds    []string
+	Records      []map[string]interface{} // after-read records, and current records for update/delete
+	Tx           *sqlx.Tx
+}
+
+// HookFunc is the lifecycle hook function. A before-hook error vetoes (rolls back) the operation;
+// an after-read hook error fails the read operation
+type HookFunc func(params *HookParamsType) error
+
+// CrudHooksType is the lifecycle hooks registry, by hook-type
+type CrudHooksType struct {
+	BeforeCreate []HookFunc
+	AfterCreate  []HookFunc
+	BeforeUpdate []HookFunc
+	AfterUpdate  []HookFunc
+	BeforeDelete []HookFunc
+	AfterDelete  []HookFunc
+	AfterRead    []HookFunc
+}
+
+var (
+	tableHooks      = map[string]CrudHooksType{}
+	tableHooksMutex sync.RWMutex
+)
+
+// RegisterTableHooks registers the lifecycle hooks for the table, for all crud-instances.
+// Table hooks run before the crud-instance hooks (CrudOptionsType.Hooks)
+func RegisterTableHooks(tableName string, hooks CrudHooksType) {
+	tableHooksMutex.Lock()
+	defer tableHooksMutex.Unlock()
+	current := tableHooks[tableName]
+	current.BeforeCreate = append(current.BeforeCreate, hooks.BeforeCreate...)
+	current.AfterCreate = append(current.AfterCreate, hooks.AfterCreate...)
+	current.BeforeUpdate = append(current.BeforeUpdate, hooks.BeforeUpdate...)
+	current.AfterUpdate = append(current.AfterUpdate, hooks.AfterUpdate...)
+	current.BeforeDelete = append(current.BeforeDelete, hooks.BeforeDelete...)
+	current.AfterDelete = append(current.AfterDelete, hooks.AfterDelete...)
+	current.AfterRead = append(current.AfterRead, hooks.AfterRead...)
+	tableHooks[tableName] = current
+}
+
+// ClearTableHooks removes the registered lifecycle hooks for the table
+func ClearTableHooks(tableName string) {
+	tableHooksMutex.Lock()
+	defer tableHooksMutex.Unlock()
+	delete(tableHooks, tableName)
+}
+
+// hookFuncs returns the hook functions of the hook-type
+func (hooks CrudHooksType) hookFuncs(hookType string) []HookFunc {
+	switch hookType {
+	case BeforeCreateHook:
+		return hooks.BeforeCreate
+	case AfterCreateHook:
+		return hooks.AfterCreate
+	case BeforeUpdateHook:
+		return hooks.BeforeUpdate
+	case AfterUpdateHook:
+		return hooks.AfterUpdate
+	case BeforeDeleteHook:
+		return hooks.BeforeDelete
+	case AfterDeleteHook:
+		return hooks.AfterDelete
+	case AfterReadHook:
+		return hooks.AfterRead
+	default:
+		return nil
+	}
+}
+
+// runHooks runs the table and crud-instance hooks of the hook-type, in registration order, stopping at the first error
+func (crud *Crud) runHooks(hookType string, params *HookParamsType) error {
+	tableHooksMutex.RLock()
+	hookFuncs := append([]HookFunc{}, tableHooks[crud.TableName].hookFuncs(hookType)...)
+	tableHooksMutex.RUnlock()
+	hookFuncs = append(hookFuncs, crud.Hooks.hookFuncs(hookType)...)
+	if len(hookFuncs) < 1 {
+		return nil
+	}
+	params.TableName = crud.TableName
+	params.TaskType = crud.TaskType
+	params.UserInfo = crud.UserInfo
+	if params.QueryParams == nil {
+		params.QueryParams = crud.QueryParams
+	}
+	if params.RecordIds == nil {
+		params.RecordIds = crud.RecordIds
+	}
+	for _, hookFunc := range hookFuncs {
+		if err := hookFunc(params); err != nil {
+			return errors.New(fmt.Sprintf("%v hook error: %v", hookType, err.Error()))
+		}
+	}
+	return nil
+}
+
+// runAfterHooks runs the after-hooks, once the transaction has committed, and returns the log-message
+// including the hook-error (if any), as the committed operation cannot be vetoed
+func (crud *Crud) runAfterHooks(hookType string, params *HookParamsType, logMessage string) string {
+	if err := crud.runHooks(hookType, params); err != nil {
+		return fmt.Sprintf("%v | %v", logMessage, err.Error())
+	}
+	return logMessage
+}
+
+// runReadHooks runs the after-read hooks, which may mutate the records, and returns the readError response
+// on the hook-error
+func (crud *Crud) runReadHooks(records *[]map[string]interface{}) (mcresponse.ResponseMessage, bool) {
+	params := &HookParamsType{Records: *records}
+	if err := crud.runHooks(AfterReadHook, params); err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading/getting records: %v", err.Error()),
+			Value:   nil,
+		}), false
+	}
+	*records = params.Records
+	return mcresponse.ResponseMessage{}, true
+}
@@ -0,0 +1,97 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: lifecycle hooks test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+type hookTestModel struct {
+	Id   string `json:"id" db:"id"`
+	Name string `json:"name" db:"name"`
+}
+
+func TestCrudHooks(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "hooks.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const hookTable = "hook_items"
+	_ = CreateTable(sqliteDb, hookTestModel{}, hookTable, "sqlite3")
+
+	var events []string
+	RegisterTableHooks(hookTable, CrudHooksType{
+		BeforeCreate: []HookFunc{func(params *HookParamsType) error {
+			for _, rec := range params.ActionParams {
+				name, _ := rec["name"].(string)
+				if name == "veto" {
+					return errors.New("vetoed")
+				}
+				rec["name"] = strings.ToUpper(name)
+			}
+			events = append(events, "beforeCreate")
+			return nil
+		}},
+	})
+	defer ClearTableHooks(hookTable)
+	hooks := CrudHooksType{
+		AfterCreate: []HookFunc{func(params *HookParamsType) error {
+			events = append(events, "afterCreate")
+			return nil
+		}},
+		AfterRead: []HookFunc{func(params *HookParamsType) error {
+			for _, rec := range params.Records {
+				rec["name"] = "redacted"
+			}
+			return nil
+		}},
+	}
+	newCrud := func() *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: hookTable},
+			CrudOptionsType{Hooks: hooks})
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should run the table and crud hooks, in order, and save the mutated records:",
+		TestFunc: func() {
+			res := newCrud().Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, strings.Join(events, ","), "beforeCreate,afterCreate", "hook events should be: beforeCreate,afterCreate")
+			var name string
+			_ = sqliteDb.QueryRow("SELECT name FROM hook_items").Scan(&name)
+			mctest.AssertEquals(t, name, "ABC", "saved name should be: ABC")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should veto the create operation, by the before-hook error, without after-hooks:",
+		TestFunc: func() {
+			events = nil
+			res := newCrud().Create(ActionParamsType{{"name": "veto"}})
+			mctest.AssertEquals(t, res.Code, "insertError", "create response-code should be: insertError")
+			mctest.AssertEquals(t, len(events), 0, "hook events length should be: 0")
+			var count int
+			_ = sqliteDb.QueryRow("SELECT COUNT(*) FROM hook_items").Scan(&count)
+			mctest.AssertEquals(t, count, 1, "records count should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should mutate the read records, by the after-read hook:",
+		TestFunc: func() {
+			res := newCrud().GetAll()
+			value, _ := res.Value.(GetResultType)
+			mctest.AssertEquals(t, res.Code, "success", "get response-code should be: success")
+			mctest.AssertEquals(t, value.Records[0]["name"], "redacted", "read name should be: redacted")
+		},
+	})
+
+	mctest.PostTestResult()
+}
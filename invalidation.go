@@ -0,0 +1,208 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: cross-instance cache invalidation bus (in-process and postgres NOTIFY)
+
+package mcdbcrud
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// CacheInvalidationChannel is the postgres notification channel of the cache invalidation bus
+const CacheInvalidationChannel = "mcdbcrud_cache_invalidation"
+
+// InvalidationMessageType is the table cache invalidation message. The invalidation is by tag (table-name):
+// the write evicts all the table entries, as the affected read-query cache-keys are not known
+type InvalidationMessageType struct {
+	TableName string `json:"table"`
+	Origin    string `json:"origin"` // publishing instance-id
+}
+
+// InvalidationHandler handles the received invalidation message
+type InvalidationHandler func(msg InvalidationMessageType)
+
+// InvalidationBus broadcasts the cache invalidations to all the app instances
+type InvalidationBus interface {
+	Publish(msg InvalidationMessageType) error
+	Subscribe(handler InvalidationHandler) error
+	Close() error
+}
+
+// instanceId identifies this app instance (process), as the invalidation message origin
+var instanceId = newInstanceId()
+
+func newInstanceId() string {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return fmt.Sprintf("%v", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(idBytes)
+}
+
+// CacheEvictHandler returns the invalidation handler that evicts the table entries, from the (local) cache
+func CacheEvictHandler(cache Cache) InvalidationHandler {
+	return func(msg InvalidationMessageType) {
+		_ = cache.DeleteByTag(msg.TableName)
+	}
+}
+
+// ListenCacheInvalidation evicts the local cache (DefaultCache, if nil), on receipt of the invalidation
+// messages from the bus. Call once per app instance, at startup
+func ListenCacheInvalidation(bus InvalidationBus, cache Cache) error {
+	if cache == nil {
+		cache = DefaultCache
+	}
+	return bus.Subscribe(CacheEvictHandler(cache))
+}
+
+// invalidateCache evicts the table cache locally, and broadcasts the invalidation (if InvalidationBus)
+func (crud *Crud) invalidateCache() {
+	msg := InvalidationMessageType{TableName: crud.TableName, Origin: instanceId}
+	_ = crud.Cache.DeleteByTag(crud.TableName)
+	if crud.InvalidationBus != nil {
+		if err := crud.InvalidationBus.Publish(msg); err != nil {
+			// the other instances' cache entries expire by CacheExpire
+			crud.handleError(errors.New(fmt.Sprintf("cache invalidation publish error: %v", err.Error())))
+		}
+	}
+}
+
+// handleError passes the non-fatal error (not returned by the crud-operation) to the OnError handler, if specified
+func (crud *Crud) handleError(err error) {
+	if crud.OnError != nil {
+		crud.OnError(err)
+	}
+}
+
+// InProcessInvalidationBus delivers the invalidation messages to the subscribers in the same process
+type InProcessInvalidationBus struct {
+	handlers []InvalidationHandler
+	mutex    sync.RWMutex
+}
+
+// NewInProcessInvalidationBus constructor returns a new in-process invalidation bus instance
+func NewInProcessInvalidationBus() *InProcessInvalidationBus {
+	return &InProcessInvalidationBus{}
+}
+
+func (bus *InProcessInvalidationBus) Publish(msg InvalidationMessageType) error {
+	bus.mutex.RLock()
+	handlers := append([]InvalidationHandler{}, bus.handlers...)
+	bus.mutex.RUnlock()
+	for _, handler := range handlers {
+		handler(msg)
+	}
+	return nil
+}
+
+func (bus *InProcessInvalidationBus) Subscribe(handler InvalidationHandler) error {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.handlers = append(bus.handlers, handler)
+	return nil
+}
+
+func (bus *InProcessInvalidationBus) Close() error {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.handlers = nil
+	return nil
+}
+
+// PgNotifyInvalidationBus broadcasts the invalidation messages, via postgres NOTIFY, to the app instances
+// listening on the same database. Messages from this instance are skipped, as already evicted locally
+type PgNotifyInvalidationBus struct {
+	appDb    *sqlx.DB
+	listener *pq.Listener
+	handlers []InvalidationHandler
+	mutex    sync.RWMutex
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewPgNotifyInvalidationBus constructor returns a new postgres NOTIFY invalidation bus instance, publishing
+// by appDb and listening by a dedicated (auto-reconnecting) connection of the connection-string
+func NewPgNotifyInvalidationBus(appDb *sqlx.DB, connectionString string) (*PgNotifyInvalidationBus, error) {
+	if appDb == nil || appDb.DriverName() != "postgres" {
+		return nil, errors.New("invalidation bus requires a postgres db-connection")
+	}
+	bus := &PgNotifyInvalidationBus{
+		appDb: appDb,
+		done:  make(chan struct{}),
+	}
+	bus.listener = pq.NewListener(connectionString, time.Second, time.Minute, nil)
+	if err := bus.listener.Listen(CacheInvalidationChannel); err != nil {
+		_ = bus.listener.Close()
+		return nil, errors.New(fmt.Sprintf("invalidation bus listen error: %v", err.Error()))
+	}
+	go bus.run()
+	return bus, nil
+}
+
+func (bus *PgNotifyInvalidationBus) Publish(msg InvalidationMessageType) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return errors.New(fmt.Sprintf("invalidation message error: %v", err.Error()))
+	}
+	if _, err = bus.appDb.Exec("SELECT pg_notify($1, $2)", CacheInvalidationChannel, string(payload)); err != nil {
+		return errors.New(fmt.Sprintf("invalidation publish error: %v", err.Error()))
+	}
+	return nil
+}
+
+func (bus *PgNotifyInvalidationBus) Subscribe(handler InvalidationHandler) error {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	bus.handlers = append(bus.handlers, handler)
+	return nil
+}
+
+func (bus *PgNotifyInvalidationBus) Close() error {
+	var err error
+	bus.once.Do(func() {
+		close(bus.done)
+		err = bus.listener.Close()
+	})
+	return err
+}
+
+// run delivers the received invalidation messages to the subscribers, until closed
+func (bus *PgNotifyInvalidationBus) run() {
+	for {
+		select {
+		case <-bus.done:
+			return
+		case notification, ok := <-bus.listener.Notify:
+			if !ok {
+				return
+			}
+			// nil notification: the connection was re-established; lost messages expire by CacheExpire
+			if notification == nil {
+				continue
+			}
+			msg := InvalidationMessageType{}
+			if err := json.Unmarshal([]byte(notification.Extra), &msg); err != nil || msg.Origin == instanceId {
+				continue
+			}
+			bus.mutex.RLock()
+			handlers := append([]InvalidationHandler{}, bus.handlers...)
+			bus.mutex.RUnlock()
+			for _, handler := range handlers {
+				handler(msg)
+			}
+		case <-time.After(90 * time.Second):
+			go func() {
+				_ = bus.listener.Ping()
+			}()
+		}
+	}
+}
@@ -0,0 +1,87 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: cache invalidation bus test cases, by sqlite3 db and the in-process bus
+
+package mcdbcrud
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+// failingInvalidationBus refuses all the publish requests
+type failingInvalidationBus struct{}
+
+func (bus failingInvalidationBus) Publish(msg InvalidationMessageType) error {
+	return errors.New("bus unavailable")
+}
+
+func (bus failingInvalidationBus) Subscribe(handler InvalidationHandler) error {
+	return nil
+}
+
+func (bus failingInvalidationBus) Close() error {
+	return nil
+}
+
+func TestCacheInvalidationBus(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "invalidation.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const busTable = "bus_items"
+	_ = CreateTable(sqliteDb, hookTestModel{}, busTable, "sqlite3")
+	bus := NewInProcessInvalidationBus()
+	defer bus.Close()
+	var received []InvalidationMessageType
+	_ = bus.Subscribe(func(msg InvalidationMessageType) {
+		received = append(received, msg)
+	})
+	// the other instance cache, evicted by the table tag
+	instanceCache := NewLRUCache(10)
+	_ = ListenCacheInvalidation(bus, instanceCache)
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should broadcast the table invalidation, on create and delete, and evict the table entries:",
+		TestFunc: func() {
+			newCrud := func() *Crud {
+				return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: busTable},
+					CrudOptionsType{InvalidationBus: bus})
+			}
+			_ = instanceCache.Set("bus-items-read", 1, 0, busTable)
+			_ = instanceCache.Set("other-read", 2, 0, "other_items")
+			res := newCrud().Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			_, tableOk := instanceCache.Get("bus-items-read")
+			_, otherOk := instanceCache.Get("other-read")
+			mctest.AssertEquals(t, tableOk, false, "instance table entry should be: evicted")
+			mctest.AssertEquals(t, otherOk, true, "instance other-table entry should be: found")
+			value, _ := res.Value.(CrudResultType)
+			_ = newCrud().DeleteById(value.RecordIds[0])
+			mctest.AssertEquals(t, len(received), 2, "received invalidations should be: 2")
+			mctest.AssertEquals(t, received[0].TableName, busTable, "invalidation table should be: "+busTable)
+			mctest.AssertEquals(t, received[0].Origin, instanceId, "invalidation origin should be: this instance")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should pass the invalidation publish error to the OnError handler, without failing the write:",
+		TestFunc: func() {
+			var handledErrs []error
+			crud := NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: busTable},
+				CrudOptionsType{InvalidationBus: failingInvalidationBus{}, OnError: func(err error) {
+					handledErrs = append(handledErrs, err)
+				}})
+			res := crud.Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, len(handledErrs), 1, "handled errors should be: 1")
+			mctest.AssertEquals(t, handledErrs[0].Error(), "cache invalidation publish error: bus unavailable", "handled error should be: the publish error")
+		},
+	})
+
+	mctest.PostTestResult()
+}
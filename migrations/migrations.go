@@ -0,0 +1,466 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: versioned schema migrations (up/down), by go-functions or .sql files, for PostgresSQL, MySQL, SQLite3
+
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// MigrationFunc performs the migration-task within the migration transaction
+type MigrationFunc func(tx *sqlx.Tx) error
+
+// Migration specifies a versioned migration, by go-functions (Up/Down) or sql-scripts (UpSQL/DownSQL)
+type Migration struct {
+	Version int64
+	Name    string
+	Up      MigrationFunc
+	Down    MigrationFunc
+	UpSQL   string
+	DownSQL string
+}
+
+// MigrationStatusType reports the applied-status of a registered migration
+type MigrationStatusType struct {
+	Version   int64      `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"appliedAt"`
+}
+
+// MigratorOptionsType is the optional settings for the Migrator
+type MigratorOptionsType struct {
+	TableName   string        // migrations tracking table, default: schema_migrations
+	LockKey     int64         // advisory-lock key, default: 7202603
+	LockTimeout time.Duration // default: 60 seconds
+	LockStale   time.Duration // sqlite3 lock-row age, to take over the lock of a crashed process, default: 10 minutes
+}
+
+// Migrator applies/reverts the registered migrations, tracked by the migrations table
+type Migrator struct {
+	Db          *sqlx.DB
+	DbType      string
+	TableName   string
+	LockKey     int64
+	LockTimeout time.Duration
+	LockStale   time.Duration
+	migrations  []Migration
+}
+
+const (
+	DefaultTableName   = "schema_migrations"
+	DefaultLockKey     = 7202603
+	DefaultLockTimeout = 60 * time.Second
+	DefaultLockStale   = 10 * time.Minute
+)
+
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_([A-Za-z0-9_\-]+)\.(up|down)\.sql$`)
+
+// NewMigrator constructor returns a new migrator-instance for the db-connection and db-type
+// (postgres, mysql, mariadb or sqlite3)
+func NewMigrator(db *sqlx.DB, dbType string, options MigratorOptionsType) *Migrator {
+	migrator := &Migrator{
+		Db:          db,
+		DbType:      dbType,
+		TableName:   options.TableName,
+		LockKey:     options.LockKey,
+		LockTimeout: options.LockTimeout,
+		LockStale:   options.LockStale,
+	}
+	// default values
+	if migrator.TableName == "" {
+		migrator.TableName = DefaultTableName
+	}
+	if migrator.LockKey == 0 {
+		migrator.LockKey = DefaultLockKey
+	}
+	if migrator.LockTimeout <= 0 {
+		migrator.LockTimeout = DefaultLockTimeout
+	}
+	if migrator.LockStale <= 0 {
+		migrator.LockStale = DefaultLockStale
+	}
+	return migrator
+}
+
+// Add registers the migrations, by unique version
+func (m *Migrator) Add(migrations ...Migration) error {
+	for _, migration := range migrations {
+		if migration.Version < 1 {
+			return errors.New(fmt.Sprintf("migration[%v]: version must be greater than 0", migration.Name))
+		}
+		if migration.Up == nil && migration.UpSQL == "" {
+			return errors.New(fmt.Sprintf("migration[%v]: up function or sql-script is required", migration.Version))
+		}
+		for _, mItem := range m.migrations {
+			if mItem.Version == migration.Version {
+				return errors.New(fmt.Sprintf("migration[%v]: duplicate version", migration.Version))
+			}
+		}
+		m.migrations = append(m.migrations, migration)
+	}
+	sort.Slice(m.migrations, func(i, j int) bool {
+		return m.migrations[i].Version < m.migrations[j].Version
+	})
+	return nil
+}
+
+// AddFS registers the .sql migrations from the dir of the file-system (e.g. embed.FS),
+// named as <version>_<name>.up.sql and <version>_<name>.down.sql
+func (m *Migrator) AddFS(fsys fs.FS, dir string) error {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return errors.New(fmt.Sprintf("error reading migrations directory[%v]: %v", dir, err.Error()))
+	}
+	fileMigrations := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matches := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if matches == nil {
+			continue
+		}
+		version, vErr := strconv.ParseInt(matches[1], 10, 64)
+		if vErr != nil {
+			return errors.New(fmt.Sprintf("migration-file[%v]: invalid version: %v", entry.Name(), vErr.Error()))
+		}
+		script, rErr := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if rErr != nil {
+			return errors.New(fmt.Sprintf("error reading migration-file[%v]: %v", entry.Name(), rErr.Error()))
+		}
+		migration, ok := fileMigrations[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: matches[2]}
+			fileMigrations[version] = migration
+		}
+		if matches[3] == "up" {
+			migration.UpSQL = string(script)
+		} else {
+			migration.DownSQL = string(script)
+		}
+	}
+	var migrations []Migration
+	for _, migration := range fileMigrations {
+		migrations = append(migrations, *migration)
+	}
+	return m.Add(migrations...)
+}
+
+// Migrations returns the registered migrations, in version order
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Up applies all the pending migrations, in version order, and returns the applied versions
+func (m *Migrator) Up() ([]int64, error) {
+	if len(m.migrations) < 1 {
+		return nil, nil
+	}
+	return m.migrateTo(m.migrations[len(m.migrations)-1].Version)
+}
+
+// Down reverts the latest applied migration, and returns the reverted version (0 if none applied)
+func (m *Migrator) Down() (int64, error) {
+	var reverted int64
+	err := m.withLock(func(conn *sqlx.Conn) error {
+		applied, aErr := m.appliedVersions(conn)
+		if aErr != nil {
+			return aErr
+		}
+		var current int64
+		for version := range applied {
+			if version > current {
+				current = version
+			}
+		}
+		if current == 0 {
+			return nil
+		}
+		migration, ok := m.migration(current)
+		if !ok {
+			return errors.New(fmt.Sprintf("migration[%v]: applied version is not registered", current))
+		}
+		if dErr := m.revert(conn, migration); dErr != nil {
+			return dErr
+		}
+		reverted = current
+		return nil
+	})
+	return reverted, err
+}
+
+// To migrates up or down to the specified version (0 reverts all applied migrations),
+// and returns the applied or reverted versions
+func (m *Migrator) To(version int64) ([]int64, error) {
+	if version < 0 {
+		return nil, errors.New("version must be 0 or greater")
+	}
+	if version > 0 {
+		if _, ok := m.migration(version); !ok {
+			return nil, errors.New(fmt.Sprintf("migration[%v]: version is not registered", version))
+		}
+	}
+	return m.migrateTo(version)
+}
+
+// Status returns the applied-status of the registered migrations, in version order
+func (m *Migrator) Status() ([]MigrationStatusType, error) {
+	var statuses []MigrationStatusType
+	err := m.withConn(func(conn *sqlx.Conn) error {
+		applied, aErr := m.appliedVersions(conn)
+		if aErr != nil {
+			return aErr
+		}
+		for _, migration := range m.migrations {
+			status := MigrationStatusType{Version: migration.Version, Name: migration.Name}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				at := appliedAt
+				status.Applied = true
+				status.AppliedAt = &at
+			}
+			statuses = append(statuses, status)
+		}
+		return nil
+	})
+	return statuses, err
+}
+
+// migrateTo applies the pending migrations up to the version, or reverts the applied migrations above the version
+func (m *Migrator) migrateTo(target int64) ([]int64, error) {
+	var versions []int64
+	err := m.withLock(func(conn *sqlx.Conn) error {
+		applied, aErr := m.appliedVersions(conn)
+		if aErr != nil {
+			return aErr
+		}
+		// apply pending migrations, in ascending order
+		for _, migration := range m.migrations {
+			if _, ok := applied[migration.Version]; ok || migration.Version > target {
+				continue
+			}
+			if uErr := m.apply(conn, migration); uErr != nil {
+				return uErr
+			}
+			versions = append(versions, migration.Version)
+		}
+		// revert applied migrations above the target, in descending order
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok || migration.Version <= target {
+				continue
+			}
+			if dErr := m.revert(conn, migration); dErr != nil {
+				return dErr
+			}
+			versions = append(versions, migration.Version)
+		}
+		return nil
+	})
+	return versions, err
+}
+
+func (m *Migrator) migration(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// apply performs the up-migration and records the version, in a transaction
+func (m *Migrator) apply(conn *sqlx.Conn, migration Migration) error {
+	return m.inTx(conn, migration, func(tx *sqlx.Tx) error {
+		if migration.Up != nil {
+			if err := migration.Up(tx); err != nil {
+				return err
+			}
+		} else if _, err := tx.Exec(migration.UpSQL); err != nil {
+			return err
+		}
+		insertQuery := tx.Rebind(fmt.Sprintf("INSERT INTO %v(version, name, applied_at) VALUES (?, ?, ?)", m.TableName))
+		_, err := tx.Exec(insertQuery, migration.Version, migration.Name, time.Now().UTC())
+		return err
+	})
+}
+
+// revert performs the down-migration and removes the version record, in a transaction
+func (m *Migrator) revert(conn *sqlx.Conn, migration Migration) error {
+	if migration.Down == nil && migration.DownSQL == "" {
+		return errors.New(fmt.Sprintf("migration[%v]: down function or sql-script is required to revert", migration.Version))
+	}
+	return m.inTx(conn, migration, func(tx *sqlx.Tx) error {
+		if migration.Down != nil {
+			if err := migration.Down(tx); err != nil {
+				return err
+			}
+		} else if _, err := tx.Exec(migration.DownSQL); err != nil {
+			return err
+		}
+		deleteQuery := tx.Rebind(fmt.Sprintf("DELETE FROM %v WHERE version=?", m.TableName))
+		_, err := tx.Exec(deleteQuery, migration.Version)
+		return err
+	})
+}
+
+// inTx runs the migration-task in a transaction, on the locked connection.
+// Note: MySQL/MariaDB implicitly commits DDL statements
+func (m *Migrator) inTx(conn *sqlx.Conn, migration Migration, task func(tx *sqlx.Tx) error) error {
+	tx, txErr := conn.BeginTxx(context.Background(), nil)
+	if txErr != nil {
+		return errors.New(fmt.Sprintf("migration[%v]: error starting transaction: %v", migration.Version, txErr.Error()))
+	}
+	if err := task(tx); err != nil {
+		_ = tx.Rollback()
+		return errors.New(fmt.Sprintf("migration[%v_%v]: %v", migration.Version, migration.Name, err.Error()))
+	}
+	if err := tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return errors.New(fmt.Sprintf("migration[%v]: error committing transaction: %v", migration.Version, err.Error()))
+	}
+	return nil
+}
+
+// appliedVersions returns the applied versions and the applied-at time
+func (m *Migrator) appliedVersions(conn *sqlx.Conn) (map[int64]time.Time, error) {
+	applied := map[int64]time.Time{}
+	rows, err := conn.QueryxContext(context.Background(), fmt.Sprintf("SELECT version, applied_at FROM %v", m.TableName))
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("error reading applied migrations: %v", err.Error()))
+	}
+	defer func(rows *sqlx.Rows) {
+		err := rows.Close()
+		if err != nil {
+
+		}
+	}(rows)
+	for rows.Next() {
+		var (
+			version   int64
+			appliedAt time.Time
+		)
+		if sErr := rows.Scan(&version, &appliedAt); sErr != nil {
+			return nil, errors.New(fmt.Sprintf("error reading applied migrations: %v", sErr.Error()))
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// withConn runs the task on a dedicated connection, after ensuring the migrations table exists
+func (m *Migrator) withConn(task func(conn *sqlx.Conn) error) error {
+	if m.Db == nil {
+		return errors.New("db-connection is required")
+	}
+	conn, err := m.Db.Connx(context.Background())
+	if err != nil {
+		return errors.New(fmt.Sprintf("Database Connection Error: %v", err.Error()))
+	}
+	defer func(conn *sqlx.Conn) {
+		_ = conn.Close()
+	}(conn)
+	tableQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (version BIGINT PRIMARY KEY, name VARCHAR(255) NOT NULL, applied_at TIMESTAMP NOT NULL)", m.TableName)
+	if _, tErr := conn.ExecContext(context.Background(), tableQuery); tErr != nil {
+		return errors.New(fmt.Sprintf("error creating migrations table[%v]: %v", m.TableName, tErr.Error()))
+	}
+	return task(conn)
+}
+
+// withLock runs the task on a dedicated connection, holding the migrations advisory-lock,
+// so that concurrent app-instances do not race on the same migrations
+func (m *Migrator) withLock(task func(conn *sqlx.Conn) error) error {
+	return m.withConn(func(conn *sqlx.Conn) error {
+		unlock, lErr := m.lock(conn)
+		if lErr != nil {
+			return lErr
+		}
+		defer unlock()
+		return task(conn)
+	})
+}
+
+// lock acquires the db-type specific advisory-lock, and returns the unlock function
+func (m *Migrator) lock(conn *sqlx.Conn) (func(), error) {
+	ctx := context.Background()
+	switch m.DbType {
+	case "postgres":
+		lockCtx, cancel := context.WithTimeout(ctx, m.LockTimeout)
+		defer cancel()
+		if _, err := conn.ExecContext(lockCtx, "SELECT pg_advisory_lock($1)", m.LockKey); err != nil {
+			return nil, errors.New(fmt.Sprintf("error acquiring migrations lock: %v", err.Error()))
+		}
+		return func() {
+			_, _ = conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", m.LockKey)
+		}, nil
+	case "mysql", "mariadb":
+		lockName := fmt.Sprintf("%v_%v", m.TableName, m.LockKey)
+		var locked sql.NullInt64
+		if err := conn.QueryRowxContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(m.LockTimeout.Seconds())).Scan(&locked); err != nil {
+			return nil, errors.New(fmt.Sprintf("error acquiring migrations lock: %v", err.Error()))
+		}
+		if !locked.Valid || locked.Int64 != 1 {
+			return nil, errors.New("error acquiring migrations lock: lock timeout")
+		}
+		return func() {
+			_, _ = conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", lockName)
+		}, nil
+	case "sqlite3":
+		// no advisory-locks: use a lock-table row, as the cross-process lock
+		lockTable := m.TableName + "_lock"
+		tableQuery := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %v (id INTEGER PRIMARY KEY, locked_at TIMESTAMP NOT NULL)", lockTable)
+		if _, err := conn.ExecContext(ctx, tableQuery); err != nil {
+			return nil, errors.New(fmt.Sprintf("error creating migrations lock table: %v", err.Error()))
+		}
+		deadline := time.Now().Add(m.LockTimeout)
+		lockQuery := fmt.Sprintf("INSERT INTO %v(id, locked_at) VALUES (1, ?)", lockTable)
+		for {
+			_, err := conn.ExecContext(ctx, lockQuery, time.Now().UTC())
+			if err == nil {
+				break
+			}
+			if m.takeOverStaleLock(conn, lockTable) {
+				continue
+			}
+			if time.Now().After(deadline) {
+				return nil, errors.New(fmt.Sprintf("error acquiring migrations lock: %v", err.Error()))
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+		return func() {
+			_, _ = conn.ExecContext(ctx, fmt.Sprintf("DELETE FROM %v WHERE id=1", lockTable))
+		}, nil
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown db-type(%v)", m.DbType))
+	}
+}
+
+// takeOverStaleLock removes the sqlite3 lock-row older than LockStale (e.g. left by a crashed process),
+// and returns whether the lock may be re-acquired
+func (m *Migrator) takeOverStaleLock(conn *sqlx.Conn, lockTable string) bool {
+	ctx := context.Background()
+	var lockedAt time.Time
+	lockQuery := fmt.Sprintf("SELECT locked_at FROM %v WHERE id=1", lockTable)
+	if err := conn.QueryRowxContext(ctx, lockQuery).Scan(&lockedAt); err != nil || time.Since(lockedAt) < m.LockStale {
+		return false
+	}
+	// by the read locked_at, so that only one of the waiting processes takes over the lock
+	res, err := conn.ExecContext(ctx, fmt.Sprintf("DELETE FROM %v WHERE id=1 AND locked_at=?", lockTable), lockedAt)
+	if err != nil {
+		return false
+	}
+	rowsAffected, _ := res.RowsAffected()
+	return rowsAffected > 0
+}
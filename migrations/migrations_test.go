@@ -0,0 +1,96 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: schema migrations test cases, by sqlite3 db
+
+package migrations
+
+import (
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func TestMigrations(t *testing.T) {
+	db, err := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "migrations.db"))
+	if err != nil {
+		t.Fatalf("error opening sqlite3 db: %v", err)
+	}
+	defer db.Close()
+
+	migrator := NewMigrator(db, "sqlite3", MigratorOptionsType{})
+	fsys := fstest.MapFS{
+		"sql/2_create_roles.up.sql":   {Data: []byte("CREATE TABLE roles (id INTEGER PRIMARY KEY, name TEXT)")},
+		"sql/2_create_roles.down.sql": {Data: []byte("DROP TABLE roles")},
+	}
+	fsErr := migrator.AddFS(fsys, "sql")
+	addErr := migrator.Add(Migration{
+		Version: 1,
+		Name:    "create_users",
+		Up: func(tx *sqlx.Tx) error {
+			_, err := tx.Exec("CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+			return err
+		},
+		Down: func(tx *sqlx.Tx) error {
+			_, err := tx.Exec("DROP TABLE users")
+			return err
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should register go-function and sql-file migrations, in version order:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, fsErr, nil, "add-fs error should be: nil")
+			mctest.AssertEquals(t, addErr, nil, "add error should be: nil")
+			mctest.AssertEquals(t, len(migrator.Migrations()), 2, "migrations length should be: 2")
+			mctest.AssertEquals(t, migrator.Migrations()[0].Version, int64(1), "first migration version should be: 1")
+			dupErr := migrator.Add(Migration{Version: 1, Name: "duplicate", UpSQL: "SELECT 1"})
+			mctest.AssertEquals(t, dupErr != nil, true, "duplicate version error should be: not nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should apply all pending migrations and record the versions:",
+		TestFunc: func() {
+			versions, upErr := migrator.Up()
+			mctest.AssertEquals(t, upErr, nil, "up error should be: nil")
+			mctest.AssertEquals(t, len(versions), 2, "applied versions length should be: 2")
+			statuses, _ := migrator.Status()
+			mctest.AssertEquals(t, statuses[1].Applied, true, "migration-2 status should be: applied")
+			versions, _ = migrator.Up()
+			mctest.AssertEquals(t, len(versions), 0, "re-applied versions length should be: 0")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should revert the latest migration, and migrate to version 0:",
+		TestFunc: func() {
+			reverted, downErr := migrator.Down()
+			mctest.AssertEquals(t, downErr, nil, "down error should be: nil")
+			mctest.AssertEquals(t, reverted, int64(2), "reverted version should be: 2")
+			versions, toErr := migrator.To(0)
+			mctest.AssertEquals(t, toErr, nil, "to error should be: nil")
+			mctest.AssertEquals(t, len(versions), 1, "reverted versions length should be: 1")
+			statuses, _ := migrator.Status()
+			mctest.AssertEquals(t, statuses[0].Applied, false, "migration-1 status should be: not applied")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should take over the stale sqlite3 lock, of a crashed process:",
+		TestFunc: func() {
+			lockMigrator := NewMigrator(db, "sqlite3", MigratorOptionsType{LockTimeout: time.Second, LockStale: time.Minute})
+			_ = lockMigrator.Add(Migration{Version: 1, Name: "noop", UpSQL: "SELECT 1"})
+			_, _ = db.Exec("INSERT INTO schema_migrations_lock(id, locked_at) VALUES (1, ?)", time.Now().UTC())
+			_, upErr := lockMigrator.Up()
+			mctest.AssertEquals(t, upErr != nil, true, "recent lock up error should be: not nil")
+			_, _ = db.Exec("UPDATE schema_migrations_lock SET locked_at=? WHERE id=1", time.Now().UTC().Add(-time.Hour))
+			versions, upErr := lockMigrator.Up()
+			mctest.AssertEquals(t, upErr, nil, "stale lock up error should be: nil")
+			mctest.AssertEquals(t, len(versions), 1, "applied versions length should be: 1")
+		},
+	})
+
+	mctest.PostTestResult()
+}
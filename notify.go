@@ -0,0 +1,260 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: postgres LISTEN/NOTIFY change-trigger installer and change-subscription (live change feeds)
+
+package mcdbcrud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// ChangeNotifyFunction is the shared trigger-function, installed by InstallChangeTrigger
+const ChangeNotifyFunction = "mcdbcrud_notify_change"
+
+// changePayloadLimit is the pg_notify payload (8000 bytes) limit, with the fields
+const changePayloadLimit = 8000
+
+// ChangeEventType is the decoded change notification, from the table change-trigger
+type ChangeEventType struct {
+	TableName string   `json:"table"`
+	Operation string   `json:"op"` // create, update or delete
+	Id        string   `json:"id"`
+	Fields    []string `json:"fields"` // changed fields (update), or all fields (create), omitted if over the payload limit
+}
+
+// ChangeHandler handles the table change-event
+type ChangeHandler func(event ChangeEventType)
+
+// ChangeChannel returns the notification channel of the table
+func ChangeChannel(tableName string) string {
+	return fmt.Sprintf("mcdbcrud_%v_changes", tableName)
+}
+
+// changeTriggerName returns the table change-trigger name, by the unqualified (schema) table-name
+func changeTriggerName(tableName string) string {
+	if _, baseTable, qualified := strings.Cut(tableName, "."); qualified {
+		tableName = baseTable
+	}
+	return fmt.Sprintf("%v_notify_change", tableName)
+}
+
+// InstallChangeTrigger installs (or replaces) the after insert/update/delete trigger, for the table,
+// that notifies the table change-channel (postgres only)
+func InstallChangeTrigger(appDb *sqlx.DB, tableName string) error {
+	if appDb == nil || appDb.DriverName() != "postgres" {
+		return errors.New("change-trigger requires a postgres db-connection")
+	}
+	functionScript := fmt.Sprintf(`CREATE OR REPLACE FUNCTION %v() RETURNS trigger AS $$
+DECLARE
+	rec_new jsonb;
+	rec_old jsonb;
+	changed text[] := '{}';
+	payload text;
+BEGIN
+	IF TG_OP <> 'DELETE' THEN
+		rec_new := to_jsonb(NEW);
+	END IF;
+	IF TG_OP <> 'INSERT' THEN
+		rec_old := to_jsonb(OLD);
+	END IF;
+	IF TG_OP = 'UPDATE' THEN
+		SELECT COALESCE(array_agg(n.key), '{}') INTO changed FROM jsonb_each(rec_new) n WHERE n.value IS DISTINCT FROM rec_old -> n.key;
+	ELSIF TG_OP = 'INSERT' THEN
+		SELECT COALESCE(array_agg(k), '{}') INTO changed FROM jsonb_object_keys(rec_new) k;
+	END IF;
+	payload := json_build_object('table', TG_TABLE_NAME, 'op', lower(TG_OP), 'id', COALESCE(rec_new ->> 'id', rec_old ->> 'id'), 'fields', changed)::text;
+	-- pg_notify payload limit (8000 bytes): the changed fields are omitted
+	IF octet_length(payload) >= %v THEN
+		payload := json_build_object('table', TG_TABLE_NAME, 'op', lower(TG_OP), 'id', COALESCE(rec_new ->> 'id', rec_old ->> 'id'))::text;
+	END IF;
+	PERFORM pg_notify(TG_ARGV[0], payload);
+	RETURN NULL;
+END;
+$$ LANGUAGE plpgsql`, ChangeNotifyFunction, changePayloadLimit)
+	triggerName := changeTriggerName(tableName)
+	scripts := []string{
+		functionScript,
+		fmt.Sprintf("DROP TRIGGER IF EXISTS %v ON %v", triggerName, tableName),
+		fmt.Sprintf("CREATE TRIGGER %v AFTER INSERT OR UPDATE OR DELETE ON %v FOR EACH ROW EXECUTE PROCEDURE %v('%v')", triggerName, tableName, ChangeNotifyFunction, ChangeChannel(tableName)),
+	}
+	tx, err := appDb.Beginx()
+	if err != nil {
+		return errors.New(fmt.Sprintf("change-trigger error: %v", err.Error()))
+	}
+	for _, script := range scripts {
+		if _, err = tx.Exec(script); err != nil {
+			_ = tx.Rollback()
+			return errors.New(fmt.Sprintf("change-trigger error[%v]: %v", tableName, err.Error()))
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		return errors.New(fmt.Sprintf("change-trigger error: %v", err.Error()))
+	}
+	return nil
+}
+
+// DropChangeTrigger removes the table change-trigger
+func DropChangeTrigger(appDb *sqlx.DB, tableName string) error {
+	if _, err := appDb.Exec(fmt.Sprintf("DROP TRIGGER IF EXISTS %v ON %v", changeTriggerName(tableName), tableName)); err != nil {
+		return errors.New(fmt.Sprintf("change-trigger error[%v]: %v", tableName, err.Error()))
+	}
+	return nil
+}
+
+// ChangeSubscriberOptionsType is the change-subscriber options
+type ChangeSubscriberOptionsType struct {
+	MinReconnectInterval time.Duration // default: 1 second
+	MaxReconnectInterval time.Duration // default: 1 minute
+	PingInterval         time.Duration // idle connection check, default: 90 seconds
+	OnReconnect          func()        // notifications may be lost while disconnected, e.g. flush the cache
+	OnError              func(err error)
+}
+
+// ChangeSubscriber delivers the table change-events, via a dedicated pq.Listener connection,
+// that reconnects (and re-listens) automatically
+type ChangeSubscriber struct {
+	ChangeSubscriberOptionsType
+	listener *pq.Listener
+	handlers map[string][]ChangeHandler // by channel
+	mutex    sync.RWMutex
+	done     chan struct{}
+	once     sync.Once
+}
+
+// NewChangeSubscriber constructor returns a new change-subscriber instance, for the postgres connection-string
+// (see DbConfig.PostgresConnectionString)
+func NewChangeSubscriber(connectionString string, options ChangeSubscriberOptionsType) *ChangeSubscriber {
+	// default values
+	if options.MinReconnectInterval <= 0 {
+		options.MinReconnectInterval = time.Second
+	}
+	if options.MaxReconnectInterval <= 0 {
+		options.MaxReconnectInterval = time.Minute
+	}
+	if options.PingInterval <= 0 {
+		options.PingInterval = 90 * time.Second
+	}
+	subscriber := &ChangeSubscriber{
+		ChangeSubscriberOptionsType: options,
+		handlers:                    map[string][]ChangeHandler{},
+		done:                        make(chan struct{}),
+	}
+	subscriber.listener = pq.NewListener(connectionString, options.MinReconnectInterval, options.MaxReconnectInterval,
+		func(event pq.ListenerEventType, err error) {
+			if err != nil && subscriber.OnError != nil {
+				subscriber.OnError(err)
+			}
+		})
+	go subscriber.run()
+	return subscriber
+}
+
+// Subscribe registers the change-handler for the table, and listens to the table change-channel
+func (s *ChangeSubscriber) Subscribe(tableName string, handler ChangeHandler) error {
+	channel := ChangeChannel(tableName)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if len(s.handlers[channel]) < 1 {
+		if err := s.listener.Listen(channel); err != nil && !errors.Is(err, pq.ErrChannelAlreadyOpen) {
+			return errors.New(fmt.Sprintf("subscribe error[%v]: %v", tableName, err.Error()))
+		}
+	}
+	s.handlers[channel] = append(s.handlers[channel], handler)
+	return nil
+}
+
+// Unsubscribe removes the table change-handlers, and stops listening to the table change-channel
+func (s *ChangeSubscriber) Unsubscribe(tableName string) error {
+	channel := ChangeChannel(tableName)
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.handlers[channel]; !ok {
+		return nil
+	}
+	delete(s.handlers, channel)
+	if err := s.listener.Unlisten(channel); err != nil && !errors.Is(err, pq.ErrChannelNotOpen) {
+		return errors.New(fmt.Sprintf("unsubscribe error[%v]: %v", tableName, err.Error()))
+	}
+	return nil
+}
+
+// Close stops the change-subscriber, and closes the listener connection
+func (s *ChangeSubscriber) Close() error {
+	var err error
+	s.once.Do(func() {
+		close(s.done)
+		err = s.listener.Close()
+	})
+	return err
+}
+
+// run delivers the notifications to the channel handlers, until closed
+func (s *ChangeSubscriber) run() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case notification, ok := <-s.listener.Notify:
+			if !ok {
+				return
+			}
+			// nil notification: the connection was re-established
+			if notification == nil {
+				if s.OnReconnect != nil {
+					s.OnReconnect()
+				}
+				continue
+			}
+			event, err := decodeChangeEvent(notification.Extra)
+			if err != nil {
+				if s.OnError != nil {
+					s.OnError(err)
+				}
+				continue
+			}
+			s.mutex.RLock()
+			handlers := append([]ChangeHandler{}, s.handlers[notification.Channel]...)
+			s.mutex.RUnlock()
+			for _, handler := range handlers {
+				handler(event)
+			}
+		case <-time.After(s.PingInterval):
+			go func() {
+				_ = s.listener.Ping()
+			}()
+		}
+	}
+}
+
+// decodeChangeEvent decodes the change-trigger notification payload
+func decodeChangeEvent(payload string) (ChangeEventType, error) {
+	event := ChangeEventType{}
+	if err := json.Unmarshal([]byte(payload), &event); err != nil {
+		return event, errors.New(fmt.Sprintf("change-event decode error: %v", err.Error()))
+	}
+	switch event.Operation {
+	case "insert":
+		event.Operation = CreateTask
+	case "update":
+		event.Operation = UpdateTask
+	case "delete":
+		event.Operation = DeleteTask
+	}
+	return event, nil
+}
+
+// CacheInvalidationHandler returns the change-handler that evicts the table entries from the cache,
+// e.g. to invalidate the cache across the app instances sharing the same database
+func CacheInvalidationHandler(cache Cache) ChangeHandler {
+	return func(event ChangeEventType) {
+		_ = cache.DeleteByTag(event.TableName)
+	}
+}
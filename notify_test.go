@@ -0,0 +1,45 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: change-notification test cases (payload decoding; the listener requires a postgres db)
+
+package mcdbcrud
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestChangeNotify(t *testing.T) {
+	mctest.McTest(mctest.OptionValue{
+		Name: "should decode the change-trigger payload, with the crud operation names:",
+		TestFunc: func() {
+			event, err := decodeChangeEvent(`{"table":"users","op":"update","id":"abc-123","fields":["email","updated_at"]}`)
+			mctest.AssertEquals(t, err, nil, "decode error should be: nil")
+			mctest.AssertEquals(t, event.TableName, "users", "event table should be: users")
+			mctest.AssertEquals(t, event.Operation, UpdateTask, "event operation should be: update")
+			mctest.AssertEquals(t, event.Id, "abc-123", "event id should be: abc-123")
+			mctest.AssertEquals(t, strings.Join(event.Fields, ","), "email,updated_at", "event fields should be: email,updated_at")
+			event, _ = decodeChangeEvent(`{"table":"users","op":"insert","id":"1","fields":[]}`)
+			mctest.AssertEquals(t, event.Operation, CreateTask, "event operation should be: create")
+			event, err = decodeChangeEvent(`{"table":"users","op":"update","id":"abc-123"}`)
+			mctest.AssertEquals(t, err, nil, "decode error, without the fields (payload limit), should be: nil")
+			mctest.AssertEquals(t, len(event.Fields), 0, "event fields, over the payload limit, should be: 0")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should reject the change-trigger installation, for non-postgres db:",
+		TestFunc: func() {
+			sqliteDb, _ := sqlx.Open("sqlite3", ":memory:")
+			defer sqliteDb.Close()
+			err := InstallChangeTrigger(sqliteDb, "users")
+			mctest.AssertEquals(t, err != nil, true, "install error should not be: nil")
+			mctest.AssertEquals(t, ChangeChannel("users"), "mcdbcrud_users_changes", "change channel should be: mcdbcrud_users_changes")
+			mctest.AssertEquals(t, changeTriggerName("app1.users"), "users_notify_change", "schema-table trigger name should be: users_notify_change")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,265 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: transactional outbox for change events (create, update, delete), and the outbox dispatcher
+
+package mcdbcrud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const DefaultOutboxTable = "outbox_events"
+
+// OutboxModel is the outbox table model, for the change events written in the crud transaction
+type OutboxModel struct {
+	Id            int64      `json:"id" db:"id"`
+	TableName     string     `json:"tableName" db:"table_name"`
+	Operation     string     `json:"operation" db:"operation"` // create, update or delete
+	RecordIds     string     `json:"recordIds" db:"record_ids" ddl:"type:TEXT"`
+	Payload       string     `json:"payload" db:"payload" ddl:"type:TEXT"`
+	UserId        string     `json:"userId" db:"user_id" ddl:"null"`
+	CreatedAt     time.Time  `json:"createdAt" db:"created_at"`
+	Attempts      int        `json:"attempts" db:"attempts" ddl:"default:0"`
+	NextAttemptAt time.Time  `json:"nextAttemptAt" db:"next_attempt_at" ddl:"index:pending"`
+	DeliveredAt   *time.Time `json:"deliveredAt" db:"delivered_at" ddl:"index:pending"`
+	LastError     *string    `json:"lastError" db:"last_error" ddl:"type:TEXT"`
+}
+
+// OutboxEventType is the change event, as claimed from the outbox table and passed to the publisher
+type OutboxEventType struct {
+	Id        int64       `json:"id"`
+	TableName string      `json:"tableName"`
+	Operation string      `json:"operation"`
+	RecordIds []string    `json:"recordIds"`
+	Payload   interface{} `json:"payload"`
+	UserId    string      `json:"userId"`
+	CreatedAt time.Time   `json:"createdAt"`
+	Attempts  int         `json:"attempts"`
+}
+
+// Publisher publishes the outbox change events, e.g. to a message-broker. Publish errors are retried
+type Publisher interface {
+	Publish(ctx context.Context, event OutboxEventType) error
+}
+
+// PublisherFunc adapts the function as the Publisher
+type PublisherFunc func(ctx context.Context, event OutboxEventType) error
+
+func (f PublisherFunc) Publish(ctx context.Context, event OutboxEventType) error {
+	return f(ctx, event)
+}
+
+// CreateOutboxTable creates the outbox table, if not exists
+func CreateOutboxTable(appDb *sqlx.DB, dbType string, tableName string) error {
+	if tableName == "" {
+		tableName = DefaultOutboxTable
+	}
+	return CreateTable(appDb, OutboxModel{}, tableName, dbType)
+}
+
+// writeOutbox inserts the change event into the outbox table, within the crud transaction (if outbox mode)
+func (crud *Crud) writeOutbox(tx *sqlx.Tx, operation string, recordIds []string, payload interface{}) error {
+	if !crud.Outbox {
+		return nil
+	}
+	if recordIds == nil {
+		recordIds = []string{}
+	}
+	idsValue, err := json.Marshal(recordIds)
+	if err != nil {
+		return errors.New(fmt.Sprintf("outbox record-ids error: %v", err.Error()))
+	}
+	payloadValue, err := json.Marshal(payload)
+	if err != nil {
+		return errors.New(fmt.Sprintf("outbox payload error: %v", err.Error()))
+	}
+	now := time.Now().UTC()
+	outboxQuery := tx.Rebind(fmt.Sprintf("INSERT INTO %v(table_name, operation, record_ids, payload, user_id, created_at, attempts, next_attempt_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)", crud.OutboxTable))
+	if _, err = tx.Exec(outboxQuery, crud.TableName, operation, string(idsValue), string(payloadValue), crud.UserInfo.UserId, now, 0, now); err != nil {
+		return errors.New(fmt.Sprintf("outbox event error: %v", err.Error()))
+	}
+	return nil
+}
+
+// outboxRecords returns the records and record-ids of the where-condition, for the update/delete change-event:
+// read within the transaction, prior to the write, by-passing the cache and the query limit, and locked for
+// update (postgres, mysql). Returns nil, if not outbox mode
+func (crud *Crud) outboxRecords(tx *sqlx.Tx, whereQuery string, whereValues ...interface{}) ([]map[string]interface{}, []string, error) {
+	if !crud.Outbox {
+		return nil, nil, nil
+	}
+	selectQuery := fmt.Sprintf("SELECT * FROM %v %v", crud.TableName, whereQuery)
+	switch tx.DriverName() {
+	case "postgres", "mysql", "mariadb":
+		selectQuery += " FOR UPDATE"
+	}
+	rows, err := tx.Queryx(selectQuery, whereValues...)
+	if err != nil {
+		return nil, nil, errors.New(fmt.Sprintf("outbox records error: %v", err.Error()))
+	}
+	defer rows.Close()
+	var records []map[string]interface{}
+	var recordIds []string
+	for rows.Next() {
+		rec := map[string]interface{}{}
+		if err = rows.MapScan(rec); err != nil {
+			return nil, nil, errors.New(fmt.Sprintf("outbox records error: %v", err.Error()))
+		}
+		for key, value := range rec {
+			if byteValue, ok := value.([]byte); ok {
+				rec[key] = string(byteValue)
+			}
+		}
+		if id, ok := rec["id"]; ok && id != nil {
+			recordIds = append(recordIds, fmt.Sprintf("%v", id))
+		}
+		camelRec, mapErr := MapToMapCamelCase(rec, crud.FieldSeparator)
+		if mapErr != nil {
+			return nil, nil, errors.New(fmt.Sprintf("outbox records error: %v", mapErr.Error()))
+		}
+		records = append(records, camelRec)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, errors.New(fmt.Sprintf("outbox records error: %v", err.Error()))
+	}
+	return records, recordIds, nil
+}
+
+// recordIdsFromParams returns the record-ids (id field-values) of the action-params or current-records
+func recordIdsFromParams[T ~map[string]interface{}](recs []T) []string {
+	var recordIds []string
+	for _, rec := range recs {
+		if id, ok := rec["id"]; ok && id != nil {
+			recordIds = append(recordIds, fmt.Sprintf("%v", id))
+		}
+	}
+	return recordIds
+}
+
+// OutboxDispatcherOptionsType is the outbox dispatcher options
+type OutboxDispatcherOptionsType struct {
+	TableName    string        // default: outbox_events
+	BatchSize    int           // events claimed per dispatch, default: 100
+	MaxAttempts  int           // publish attempts, before the event is parked, default: 10
+	RetryDelay   time.Duration // base retry delay, doubled per attempt, default: 5 seconds
+	PollInterval time.Duration // default: 1 second
+}
+
+// OutboxDispatcher claims the pending outbox events, publishes them, and marks them delivered (or retries)
+type OutboxDispatcher struct {
+	OutboxDispatcherOptionsType
+	Db        *sqlx.DB
+	Publisher Publisher
+}
+
+// NewOutboxDispatcher constructor returns a new outbox-dispatcher instance
+func NewOutboxDispatcher(db *sqlx.DB, publisher Publisher, options OutboxDispatcherOptionsType) *OutboxDispatcher {
+	dispatcher := &OutboxDispatcher{
+		OutboxDispatcherOptionsType: options,
+		Db:                          db,
+		Publisher:                   publisher,
+	}
+	// default values
+	if dispatcher.TableName == "" {
+		dispatcher.TableName = DefaultOutboxTable
+	}
+	if dispatcher.BatchSize <= 0 {
+		dispatcher.BatchSize = 100
+	}
+	if dispatcher.MaxAttempts <= 0 {
+		dispatcher.MaxAttempts = 10
+	}
+	if dispatcher.RetryDelay <= 0 {
+		dispatcher.RetryDelay = 5 * time.Second
+	}
+	if dispatcher.PollInterval <= 0 {
+		dispatcher.PollInterval = time.Second
+	}
+	return dispatcher
+}
+
+// DispatchOnce claims a batch of the pending events (FOR UPDATE SKIP LOCKED, on postgres and mysql),
+// publishes them in order, and marks them delivered, or schedules the retry. Returns the delivered events count
+func (d *OutboxDispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	if d.Db == nil || d.Publisher == nil {
+		return 0, errors.New("db-connection and publisher are required")
+	}
+	tx, err := d.Db.BeginTxx(ctx, nil)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("outbox dispatch error: %v", err.Error()))
+	}
+	defer func(tx *sqlx.Tx) {
+		_ = tx.Rollback()
+	}(tx)
+	lockClause := ""
+	switch d.Db.DriverName() {
+	case "postgres", "mysql":
+		lockClause = " FOR UPDATE SKIP LOCKED"
+	}
+	now := time.Now().UTC()
+	claimQuery := tx.Rebind(fmt.Sprintf("SELECT id, table_name, operation, record_ids, payload, user_id, created_at, attempts FROM %v WHERE delivered_at IS NULL AND attempts < ? AND next_attempt_at <= ? ORDER BY id LIMIT %v%v", d.TableName, d.BatchSize, lockClause))
+	var events []OutboxModel
+	if err = tx.SelectContext(ctx, &events, claimQuery, d.MaxAttempts, now); err != nil {
+		return 0, errors.New(fmt.Sprintf("outbox claim error: %v", err.Error()))
+	}
+	deliveredQuery := tx.Rebind(fmt.Sprintf("UPDATE %v SET delivered_at = ?, attempts = attempts + 1, last_error = NULL WHERE id = ?", d.TableName))
+	retryQuery := tx.Rebind(fmt.Sprintf("UPDATE %v SET attempts = attempts + 1, last_error = ?, next_attempt_at = ? WHERE id = ?", d.TableName))
+	delivered := 0
+	for _, event := range events {
+		outboxEvent := OutboxEventType{
+			Id:        event.Id,
+			TableName: event.TableName,
+			Operation: event.Operation,
+			UserId:    event.UserId,
+			CreatedAt: event.CreatedAt,
+			Attempts:  event.Attempts,
+		}
+		_ = json.Unmarshal([]byte(event.RecordIds), &outboxEvent.RecordIds)
+		_ = json.Unmarshal([]byte(event.Payload), &outboxEvent.Payload)
+		if pubErr := d.Publisher.Publish(ctx, outboxEvent); pubErr != nil {
+			// exponential backoff: retryDelay * 2^attempts
+			nextAttemptAt := time.Now().UTC().Add(d.RetryDelay * time.Duration(1<<uint(min(event.Attempts, 16))))
+			if _, err = tx.ExecContext(ctx, retryQuery, pubErr.Error(), nextAttemptAt, event.Id); err != nil {
+				return delivered, errors.New(fmt.Sprintf("outbox retry error: %v", err.Error()))
+			}
+			continue
+		}
+		if _, err = tx.ExecContext(ctx, deliveredQuery, time.Now().UTC(), event.Id); err != nil {
+			return delivered, errors.New(fmt.Sprintf("outbox delivered error: %v", err.Error()))
+		}
+		delivered++
+	}
+	if err = tx.Commit(); err != nil {
+		return 0, errors.New(fmt.Sprintf("outbox dispatch error: %v", err.Error()))
+	}
+	return delivered, nil
+}
+
+// Run dispatches the pending events, by the poll-interval, until the context is cancelled
+func (d *OutboxDispatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+	for {
+		// drain the pending events, before waiting for the next poll
+		for {
+			delivered, err := d.DispatchOnce(ctx)
+			if err != nil && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil || delivered < d.BatchSize {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: transactional outbox test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestOutbox(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "outbox.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const outboxItemTable = "outbox_items"
+	_ = CreateTable(sqliteDb, hookTestModel{}, outboxItemTable, "sqlite3")
+	if err := CreateOutboxTable(sqliteDb, "sqlite3", ""); err != nil {
+		t.Fatalf("error creating outbox table: %v", err)
+	}
+	newCrud := func() *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: outboxItemTable},
+			CrudOptionsType{Outbox: true})
+	}
+	var published []OutboxEventType
+	failNext := true
+	dispatcher := NewOutboxDispatcher(sqliteDb, PublisherFunc(func(ctx context.Context, event OutboxEventType) error {
+		if failNext {
+			failNext = false
+			return errors.New("broker unavailable")
+		}
+		published = append(published, event)
+		return nil
+	}), OutboxDispatcherOptionsType{RetryDelay: 1})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the create and delete change-events, within the crud transaction:",
+		TestFunc: func() {
+			res := newCrud().Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			value, _ := res.Value.(CrudResultType)
+			res = newCrud().DeleteById(value.RecordIds[0])
+			mctest.AssertEquals(t, res.Code, "success", "delete response-code should be: success")
+			var count int
+			_ = sqliteDb.QueryRow("SELECT COUNT(*) FROM outbox_events WHERE delivered_at IS NULL").Scan(&count)
+			mctest.AssertEquals(t, count, 2, "pending outbox events should be: 2")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should retry the failed publish, and mark the events delivered, in order:",
+		TestFunc: func() {
+			delivered, err := dispatcher.DispatchOnce(context.Background())
+			mctest.AssertEquals(t, err, nil, "dispatch error should be: nil")
+			mctest.AssertEquals(t, delivered, 1, "first dispatch delivered count should be: 1")
+			delivered, _ = dispatcher.DispatchOnce(context.Background())
+			mctest.AssertEquals(t, delivered, 1, "retry dispatch delivered count should be: 1")
+			mctest.AssertEquals(t, len(published), 2, "published events should be: 2")
+			mctest.AssertEquals(t, published[0].Operation, DeleteTask, "first delivered event should be: delete")
+			mctest.AssertEquals(t, published[1].Operation, CreateTask, "retried event should be: create")
+			mctest.AssertEquals(t, published[1].Attempts, 1, "retried event attempts should be: 1")
+			delivered, _ = dispatcher.DispatchOnce(context.Background())
+			mctest.AssertEquals(t, delivered, 0, "final dispatch delivered count should be: 0")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the update-by-param change-event record-ids, without the audit-log:",
+		TestFunc: func() {
+			res := newCrud().Create(ActionParamsType{{"name": "xyz"}})
+			value, _ := res.Value.(CrudResultType)
+			crud := newCrud()
+			crud.QueryParams = QueryParamType{"name": "xyz"}
+			res = crud.UpdateByParam(ActionParamType{"name": "uvw"})
+			mctest.AssertEquals(t, res.Code, "success", "update response-code should be: success")
+			var recordIds string
+			_ = sqliteDb.QueryRow("SELECT record_ids FROM outbox_events WHERE operation=$1", UpdateTask).Scan(&recordIds)
+			mctest.AssertEquals(t, recordIds, fmt.Sprintf(`["%v"]`, value.RecordIds[0]), "update event record-ids should be: the updated record-id")
+		},
+	})
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should write the by-param change-event record-ids, within the transaction, by-passing the query limit:",
+		TestFunc: func() {
+			_ = newCrud().Create(ActionParamsType{{"name": "lim"}, {"name": "lim"}})
+			crud := newCrud()
+			crud.Limit = 1
+			crud.QueryParams = QueryParamType{"name": "lim"}
+			res := crud.UpdateByParam(ActionParamType{"name": "mil"})
+			mctest.AssertEquals(t, res.Code, "success", "update response-code should be: success")
+			var recordIds []string
+			var recordIdsJson string
+			_ = sqliteDb.QueryRow("SELECT record_ids FROM outbox_events WHERE operation=$1 ORDER BY id DESC LIMIT 1", UpdateTask).Scan(&recordIdsJson)
+			_ = json.Unmarshal([]byte(recordIdsJson), &recordIds)
+			mctest.AssertEquals(t, len(recordIds), 2, "update event record-ids should be: 2")
+			crud = newCrud()
+			crud.Limit = 1
+			crud.QueryParams = QueryParamType{"name": "mil"}
+			res = crud.DeleteByParam()
+			mctest.AssertEquals(t, res.Code, "success", "delete response-code should be: success")
+			recordIds = nil
+			_ = sqliteDb.QueryRow("SELECT record_ids FROM outbox_events WHERE operation=$1 ORDER BY id DESC LIMIT 1", DeleteTask).Scan(&recordIdsJson)
+			_ = json.Unmarshal([]byte(recordIdsJson), &recordIds)
+			mctest.AssertEquals(t, len(recordIds), 2, "delete event record-ids should be: 2")
+		},
+	})
+
+	mctest.PostTestResult()
+}
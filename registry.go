@@ -0,0 +1,134 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: db-connection registry, by connection-name (safe for concurrent use)
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// registry connection-names of the app, audit and access databases, used by NewCrud when the
+// AppDb, AuditDb or AccessDb is not specified
+const (
+	AppDbName    = "app"
+	AuditDbName  = "audit"
+	AccessDbName = "access"
+)
+
+// DbRegistry is the db-connection pools registry, by connection-name
+type DbRegistry struct {
+	connections map[string]*sqlx.DB
+	mutex       sync.Mutex
+}
+
+// DefaultDbRegistry is the process-wide db-connection registry, used by OpenDbx/CloseDbx and NewCrud
+var DefaultDbRegistry = NewDbRegistry()
+
+// NewDbRegistry constructor returns a new db-connection registry instance
+func NewDbRegistry() *DbRegistry {
+	return &DbRegistry{connections: map[string]*sqlx.DB{}}
+}
+
+// ConnectionName returns the dbConfig registry connection-name: Name, if specified, or the db-type,
+// username, host, port and db-name (filename, for sqlite3)
+func (dbConfig DbConfig) ConnectionName() string {
+	if dbConfig.Name != "" {
+		return dbConfig.Name
+	}
+	if dbConfig.DbType == "sqlite3" {
+		return fmt.Sprintf("%v:%v", dbConfig.DbType, dbConfig.Filename)
+	}
+	return fmt.Sprintf("%v:%v@%v:%v/%v", dbConfig.DbType, dbConfig.Username, dbConfig.Host, dbConfig.Port, dbConfig.DbName)
+}
+
+// Open opens and registers the db-connection pool, by name, or returns the already registered connection
+func (r *DbRegistry) Open(name string, dbConfig DbConfig) (*sqlx.DB, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if dbx, ok := r.connections[name]; ok {
+		return dbx, nil
+	}
+	dbx, err := dbConfig.openDbx()
+	if err != nil {
+		return nil, err
+	}
+	r.connections[name] = dbx
+	return dbx, nil
+}
+
+// Register registers the (externally opened) db-connection pool, by name
+func (r *DbRegistry) Register(name string, dbx *sqlx.DB) error {
+	if dbx == nil {
+		return errors.New(fmt.Sprintf("db-connection[%v] is required", name))
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if current, ok := r.connections[name]; ok && current != dbx {
+		return errors.New(fmt.Sprintf("db-connection[%v] is already registered", name))
+	}
+	r.connections[name] = dbx
+	return nil
+}
+
+// Get returns the registered db-connection pool, by name
+func (r *DbRegistry) Get(name string) (*sqlx.DB, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	dbx, ok := r.connections[name]
+	return dbx, ok
+}
+
+// Names returns the registered connection-names, in sorted order
+func (r *DbRegistry) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	var names []string
+	for name := range r.connections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close closes and removes the registered db-connection pool (and its statement cache), by name
+func (r *DbRegistry) Close(name string) error {
+	r.mutex.Lock()
+	dbx, ok := r.connections[name]
+	delete(r.connections, name)
+	r.mutex.Unlock()
+	if !ok {
+		return nil
+	}
+	CloseStmtCache(dbx)
+	if err := dbx.Close(); err != nil {
+		return errors.New(fmt.Sprintf("db-connection[%v] close error: %v", name, err.Error()))
+	}
+	return nil
+}
+
+// CloseAll closes and removes all the registered db-connection pools (and their statement caches)
+func (r *DbRegistry) CloseAll() error {
+	r.mutex.Lock()
+	connections := r.connections
+	r.connections = map[string]*sqlx.DB{}
+	r.mutex.Unlock()
+	var closeErrors []string
+	for name, dbx := range connections {
+		CloseStmtCache(dbx)
+		if err := dbx.Close(); err != nil {
+			closeErrors = append(closeErrors, fmt.Sprintf("%v: %v", name, err.Error()))
+		}
+	}
+	if len(closeErrors) > 0 {
+		sort.Strings(closeErrors)
+		return errors.New(fmt.Sprintf("db-connection close errors: %v", strings.Join(closeErrors, " | ")))
+	}
+	return nil
+}
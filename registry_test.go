@@ -0,0 +1,79 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: db-connection registry test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+)
+
+func TestDbRegistry(t *testing.T) {
+	tempDir := t.TempDir()
+	appConfig := DbConfig{DbType: "sqlite3", Filename: filepath.Join(tempDir, "app.db"), PoolSize: 4}
+	auditConfig := DbConfig{DbType: "sqlite3", Filename: filepath.Join(tempDir, "audit.db"), Name: "audit-db"}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should open and close the connections by config, without overwriting each other:",
+		TestFunc: func() {
+			appDb, err := appConfig.OpenDbx()
+			mctest.AssertEquals(t, err, nil, "open error should be: nil")
+			auditDb, _ := auditConfig.OpenDbx()
+			sameDb, _ := appConfig.OpenDbx()
+			mctest.AssertEquals(t, appDb == sameDb, true, "same config connection should be: shared")
+			mctest.AssertEquals(t, appDb != auditDb, true, "different config connections should be: different")
+			mctest.AssertEquals(t, appDb.Stats().MaxOpenConnections, 4, "max open connections should be: 4")
+			_ = GetStmtCache(auditDb)
+			auditConfig.CloseDbx()
+			_, cached := stmtCaches.Load(auditDb)
+			mctest.AssertEquals(t, cached, false, "audit connection statement cache should be: removed")
+			mctest.AssertEquals(t, appDb.Ping(), nil, "app connection ping error should be: nil")
+			mctest.AssertEquals(t, auditDb.Ping() != nil, true, "audit connection should be: closed")
+			appConfig.CloseDbx()
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should open the named connections concurrently, and close all:",
+		TestFunc: func() {
+			registry := NewDbRegistry()
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, _ = registry.Open(AppDbName, appConfig)
+					_, _ = registry.Open(AuditDbName, auditConfig)
+				}()
+			}
+			wg.Wait()
+			mctest.AssertEquals(t, strings.Join(registry.Names(), ","), "app,audit", "registered names should be: app,audit")
+			appDb, _ := registry.Get(AppDbName)
+			_ = GetStmtCache(appDb)
+			mctest.AssertEquals(t, registry.CloseAll(), nil, "close-all error should be: nil")
+			_, ok := registry.Get(AppDbName)
+			mctest.AssertEquals(t, ok, false, "app connection should be: removed")
+			_, cached := stmtCaches.Load(appDb)
+			mctest.AssertEquals(t, cached, false, "app connection statement cache should be: removed")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should use the registered app and audit databases, in NewCrud:",
+		TestFunc: func() {
+			appDb, _ := DefaultDbRegistry.Open(AppDbName, appConfig)
+			auditDb, _ := DefaultDbRegistry.Open(AuditDbName, auditConfig)
+			defer DefaultDbRegistry.Close(AppDbName)
+			defer DefaultDbRegistry.Close(AuditDbName)
+			crud := NewCrud(CrudParamsType{TableName: "users"}, CrudOptionsType{})
+			mctest.AssertEquals(t, crud.AppDb == appDb, true, "crud app-db should be: registered app-db")
+			mctest.AssertEquals(t, crud.AuditDb == auditDb, true, "crud audit-db should be: registered audit-db")
+			mctest.AssertEquals(t, crud.AccessDb == appDb, true, "crud access-db should be: app-db")
+		},
+	})
+
+	mctest.PostTestResult()
+}
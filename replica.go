@@ -0,0 +1,109 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: read-replicas routing, by selection policy (round-robin, random, least-connections)
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync/atomic"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// replica selection policies
+const (
+	ReplicaRoundRobin       = "round-robin"
+	ReplicaRandom           = "random"
+	ReplicaLeastConnections = "least-connections" // fewest in-use connections
+)
+
+// ReplicaSet is the read-replica connections, with the selection policy (safe for concurrent use)
+type ReplicaSet struct {
+	replicas []*sqlx.DB
+	policy   string
+	counter  uint64
+}
+
+// NewReplicaSet constructor returns the replica-set of the replica connections, by policy (default: round-robin)
+func NewReplicaSet(policy string, replicas ...*sqlx.DB) (*ReplicaSet, error) {
+	switch policy {
+	case "":
+		policy = ReplicaRoundRobin
+	case ReplicaRoundRobin, ReplicaRandom, ReplicaLeastConnections:
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown replica policy(%v)", policy))
+	}
+	replicaSet := &ReplicaSet{policy: policy}
+	for i, replica := range replicas {
+		if replica == nil {
+			return nil, errors.New(fmt.Sprintf("replica[%v] connection is required", i))
+		}
+		replicaSet.replicas = append(replicaSet.replicas, replica)
+	}
+	return replicaSet, nil
+}
+
+// OpenReplicaSet opens (by OpenDbx) the replica connections of the dbConfigs, and returns the replica-set
+func OpenReplicaSet(policy string, dbConfigs ...DbConfig) (*ReplicaSet, error) {
+	var replicas []*sqlx.DB
+	for _, dbConfig := range dbConfigs {
+		replica, err := dbConfig.OpenDbx()
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("replica[%v] error: %v", dbConfig.ConnectionName(), err.Error()))
+		}
+		replicas = append(replicas, replica)
+	}
+	return NewReplicaSet(policy, replicas...)
+}
+
+// Policy returns the replica selection policy
+func (rs *ReplicaSet) Policy() string {
+	return rs.policy
+}
+
+// Len returns the number of replica connections
+func (rs *ReplicaSet) Len() int {
+	return len(rs.replicas)
+}
+
+// Next returns the selected replica connection, by policy, or nil, if no replicas
+func (rs *ReplicaSet) Next() *sqlx.DB {
+	replicasLen := len(rs.replicas)
+	if replicasLen < 1 {
+		return nil
+	}
+	switch rs.policy {
+	case ReplicaRandom:
+		return rs.replicas[rand.Intn(replicasLen)]
+	case ReplicaLeastConnections:
+		// scan from the round-robin position, to spread the ties
+		start := int(atomic.AddUint64(&rs.counter, 1) % uint64(replicasLen))
+		selected := rs.replicas[start]
+		minInUse := selected.Stats().InUse
+		for i := 1; i < replicasLen; i++ {
+			replica := rs.replicas[(start+i)%replicasLen]
+			if inUse := replica.Stats().InUse; inUse < minInUse {
+				selected = replica
+				minInUse = inUse
+			}
+		}
+		return selected
+	default:
+		return rs.replicas[(atomic.AddUint64(&rs.counter, 1)-1)%uint64(replicasLen)]
+	}
+}
+
+// readDb returns the read connection: the primary (AppDb) for read-your-writes, after/within a write
+// of the crud-instance (e.g. the current-records read for audit-log) or without replicas, else the replica, by policy
+func (crud *Crud) readDb() *sqlx.DB {
+	if crud.ReadYourWrites || crud.primaryRead || crud.ReadReplicas == nil {
+		return crud.AppDb
+	}
+	if replica := crud.ReadReplicas.Next(); replica != nil {
+		return replica
+	}
+	return crud.AppDb
+}
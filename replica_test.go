@@ -0,0 +1,90 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: read-replicas routing test cases, by sqlite3 db (primary and replica files)
+
+package mcdbcrud
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestReadReplicas(t *testing.T) {
+	tempDir := t.TempDir()
+	const replicaTable = "replica_items"
+	var primaryId string
+	openDb := func(filename string, name string) *sqlx.DB {
+		db, dbErr := sqlx.Open("sqlite3", filepath.Join(tempDir, filename))
+		if dbErr != nil {
+			t.Fatalf("error opening sqlite3 db: %v", dbErr)
+		}
+		_ = CreateTable(db, hookTestModel{}, replicaTable, "sqlite3")
+		createRes := NewCrud(CrudParamsType{AppDb: db, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: replicaTable},
+			CrudOptionsType{}).Create(ActionParamsType{{"name": name}})
+		value, _ := createRes.Value.(CrudResultType)
+		if len(value.RecordIds) > 0 {
+			primaryId = value.RecordIds[0]
+		}
+		return db
+	}
+	primaryDb := openDb("primary.db", "primary")
+	primaryRecordId := primaryId
+	replicaDb1 := openDb("replica1.db", "replica1")
+	replicaDb2 := openDb("replica2.db", "replica2")
+	defer primaryDb.Close()
+	defer replicaDb1.Close()
+	defer replicaDb2.Close()
+	replicas, _ := NewReplicaSet(ReplicaRoundRobin, replicaDb1, replicaDb2)
+	newCrud := func(readYourWrites bool) *Crud {
+		return NewCrud(CrudParamsType{AppDb: primaryDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{},
+			TableName: replicaTable, ReadYourWrites: readYourWrites}, CrudOptionsType{ReadReplicas: replicas})
+	}
+	readName := func(crud *Crud) string {
+		res := crud.GetAll()
+		value, _ := res.Value.(GetResultType)
+		if len(value.Records) != 1 {
+			return res.Message
+		}
+		return value.Records[0]["name"].(string)
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should read from the replicas, by round-robin:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, readName(newCrud(false)), "replica1", "first read should be from: replica1")
+			mctest.AssertEquals(t, readName(newCrud(false)), "replica2", "second read should be from: replica2")
+			mctest.AssertEquals(t, readName(newCrud(false)), "replica1", "third read should be from: replica1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should read from the primary, for read-your-writes and after a write:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, readName(newCrud(true)), "primary", "read-your-writes read should be from: primary")
+			crud := newCrud(false)
+			updateRes := crud.UpdateById(ActionParamType{"name": "primary-updated"}, primaryRecordId)
+			mctest.AssertEquals(t, updateRes.Code, "success", "update response-code should be: success")
+			mctest.AssertEquals(t, readName(crud), "primary-updated", "read after write should be from: primary")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should select by the random and least-connections policies:",
+		TestFunc: func() {
+			randomSet, _ := NewReplicaSet(ReplicaRandom, replicaDb1, replicaDb2)
+			next := randomSet.Next()
+			mctest.AssertEquals(t, next == replicaDb1 || next == replicaDb2, true, "random replica should be: in the set")
+			leastSet, _ := NewReplicaSet(ReplicaLeastConnections, replicaDb1, replicaDb2)
+			conn, _ := replicaDb1.Conn(context.Background())
+			mctest.AssertEquals(t, leastSet.Next() == replicaDb2, true, "least-connections replica should be: replica2")
+			mctest.AssertEquals(t, leastSet.Next() == replicaDb2, true, "least-connections replica should be: replica2")
+			_ = conn.Close()
+			_, err := NewReplicaSet("fastest", replicaDb1)
+			mctest.AssertEquals(t, err != nil, true, "unknown policy error should be: not nil")
+		},
+	})
+
+	mctest.PostTestResult()
+}
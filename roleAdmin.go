@@ -0,0 +1,444 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: role and permission administration (role-services and user-roles), by the admin-user, audited
+
+package mcdbcrud
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+)
+
+// TableServiceCategory is the role-services category of the table permissions;
+// the record permissions are categorised by the table-name
+const TableServiceCategory = "table"
+
+// PermissionParamsType is the Grant and Revoke parameters: the role permissions on the table, or on the table record
+type PermissionParamsType struct {
+	RoleId    string `json:"roleId"`
+	TableName string `json:"tableName"` // registered in the ServiceTable (category: table), if not exists
+	RecordId  string `json:"recordId"`  // record permission, optional
+	CanRead   bool   `json:"canRead"`
+	CanCreate bool   `json:"canCreate"`
+	CanUpdate bool   `json:"canUpdate"`
+	CanDelete bool   `json:"canDelete"`
+}
+
+// EffectivePermissionType is the EffectivePermissions value: the merged permissions of the user roles, on the table,
+// and on the table records (by record-id)
+type EffectivePermissionType struct {
+	UserId    string                     `json:"userId"`
+	TableName string                     `json:"tableName"`
+	IsAdmin   bool                       `json:"isAdmin"`
+	RoleIds   []string                   `json:"roleIds"`
+	Table     RoleServiceType            `json:"table"`
+	Records   map[string]RoleServiceType `json:"records"`
+}
+
+// adminCheck verifies that the current user (UserInfo) has an active, admin login session
+func (crud *Crud) adminCheck() (mcresponse.ResponseMessage, bool) {
+	accessRes := crud.CheckUserAccess()
+	if accessRes.Code != "success" {
+		return accessRes, false
+	}
+	if accessInfo, ok := accessRes.Value.(AccessInfoType); !ok || !accessInfo.IsAdmin || !accessInfo.IsActive {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: "Admin access is required to administer the roles and permissions",
+			Value:   nil,
+		}), false
+	}
+	return mcresponse.ResponseMessage{}, true
+}
+
+// tableServiceId returns the service-id of the table-name, from the ServiceTable, and registers it, if not exists and register is true
+func (crud *Crud) tableServiceId(tableName string, register bool) (string, error) {
+	var serviceId string
+	serviceScript := fmt.Sprintf("SELECT id from %v WHERE name=$1", crud.ServiceTable)
+	err := crud.AccessDb.QueryRowx(serviceScript, tableName).Scan(&serviceId)
+	if errors.Is(err, sql.ErrNoRows) && register {
+		insertScript := fmt.Sprintf("INSERT INTO %v(name, category, created_by) VALUES ($1, $2, $3) RETURNING id", crud.ServiceTable)
+		err = crud.AccessDb.QueryRowx(insertScript, tableName, TableServiceCategory, crud.UserInfo.UserId).Scan(&serviceId)
+	}
+	if err != nil {
+		return "", errors.New(fmt.Sprintf("table(%v) service-id error: %v", tableName, err.Error()))
+	}
+	return serviceId, nil
+}
+
+// permissionService returns the role-services service-id and category of the permission params (table or record)
+func (crud *Crud) permissionService(params PermissionParamsType, register bool) (string, string, error) {
+	if params.RoleId == "" || params.TableName == "" {
+		return "", "", errors.New("roleId and tableName are required")
+	}
+	if params.RecordId != "" {
+		return params.RecordId, params.TableName, nil
+	}
+	serviceId, err := crud.tableServiceId(params.TableName, register)
+	return serviceId, TableServiceCategory, err
+}
+
+// Grant method grants the role permissions (CanRead, CanCreate, CanUpdate and/or CanDelete), on the table or record,
+// in addition to the existing role permissions
+func (crud *Crud) Grant(params PermissionParamsType) mcresponse.ResponseMessage {
+	if adminRes, ok := crud.adminCheck(); !ok {
+		return adminRes
+	}
+	serviceId, category, err := crud.permissionService(params, true)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	current, found, err := crud.rolePermission(params.RoleId, serviceId)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading the role permission: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	permission := RoleServiceType{
+		RoleId:          params.RoleId,
+		ServiceId:       serviceId,
+		ServiceCategory: category,
+		CanRead:         current.CanRead || params.CanRead,
+		CanCreate:       current.CanCreate || params.CanCreate,
+		CanUpdate:       current.CanUpdate || params.CanUpdate,
+		CanDelete:       current.CanDelete || params.CanDelete,
+	}
+	permission.CanCrud = permission.CanRead && permission.CanCreate && permission.CanUpdate && permission.CanDelete
+	logType := CreateTask
+	if found {
+		logType = UpdateTask
+		updateScript := fmt.Sprintf("UPDATE %v SET can_read=$1, can_create=$2, can_update=$3, can_delete=$4, can_crud=$5, is_active=$6, updated_by=$7 WHERE role_id=$8 AND service_id=$9", crud.RoleTable)
+		_, err = crud.AccessDb.Exec(updateScript, permission.CanRead, permission.CanCreate, permission.CanUpdate, permission.CanDelete,
+			permission.CanCrud, true, crud.UserInfo.UserId, permission.RoleId, permission.ServiceId)
+	} else {
+		insertScript := fmt.Sprintf("INSERT INTO %v(role_id, service_id, service_category, can_read, can_create, can_update, can_delete, can_crud, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)", crud.RoleTable)
+		_, err = crud.AccessDb.Exec(insertScript, permission.RoleId, permission.ServiceId, permission.ServiceCategory, permission.CanRead,
+			permission.CanCreate, permission.CanUpdate, permission.CanDelete, permission.CanCrud, crud.UserInfo.UserId)
+	}
+	if err != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error granting the role permission: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	crud.invalidateRoles()
+	logMessage := crud.roleAdminLog(logType, current, permission)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Role permission granted successfully [log-message: %v]", logMessage),
+		Value:   permission,
+	})
+}
+
+// Revoke method revokes the specified role permissions (CanRead, CanCreate, CanUpdate and/or CanDelete),
+// on the table or record, or all the role permissions, if none is specified
+func (crud *Crud) Revoke(params PermissionParamsType) mcresponse.ResponseMessage {
+	if adminRes, ok := crud.adminCheck(); !ok {
+		return adminRes
+	}
+	serviceId, _, err := crud.permissionService(params, false)
+	if err != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: err.Error(),
+			Value:   nil,
+		})
+	}
+	current, found, err := crud.rolePermission(params.RoleId, serviceId)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading the role permission: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if !found {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "Role permission not found",
+			Value:   nil,
+		})
+	}
+	revokeAll := !params.CanRead && !params.CanCreate && !params.CanUpdate && !params.CanDelete
+	permission := current
+	permission.CanRead = current.CanRead && !params.CanRead && !revokeAll
+	permission.CanCreate = current.CanCreate && !params.CanCreate && !revokeAll
+	permission.CanUpdate = current.CanUpdate && !params.CanUpdate && !revokeAll
+	permission.CanDelete = current.CanDelete && !params.CanDelete && !revokeAll
+	permission.CanCrud = permission.CanRead && permission.CanCreate && permission.CanUpdate && permission.CanDelete
+	logType := UpdateTask
+	if !permission.CanRead && !permission.CanCreate && !permission.CanUpdate && !permission.CanDelete {
+		logType = DeleteTask
+		delScript := fmt.Sprintf("DELETE FROM %v WHERE role_id=$1 AND service_id=$2", crud.RoleTable)
+		_, err = crud.AccessDb.Exec(delScript, permission.RoleId, permission.ServiceId)
+	} else {
+		updateScript := fmt.Sprintf("UPDATE %v SET can_read=$1, can_create=$2, can_update=$3, can_delete=$4, can_crud=$5, updated_by=$6 WHERE role_id=$7 AND service_id=$8", crud.RoleTable)
+		_, err = crud.AccessDb.Exec(updateScript, permission.CanRead, permission.CanCreate, permission.CanUpdate, permission.CanDelete,
+			permission.CanCrud, crud.UserInfo.UserId, permission.RoleId, permission.ServiceId)
+	}
+	if err != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error revoking the role permission: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	crud.invalidateRoles()
+	logMessage := crud.roleAdminLog(logType, current, permission)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Role permission revoked successfully [log-message: %v]", logMessage),
+		Value:   permission,
+	})
+}
+
+// ListPermissions method returns the active role permissions ([]RoleServiceType), on the tables and records
+func (crud *Crud) ListPermissions(roleId string) mcresponse.ResponseMessage {
+	if adminRes, ok := crud.adminCheck(); !ok {
+		return adminRes
+	}
+	roleScript := fmt.Sprintf("SELECT role_id, service_id, service_category, can_read, can_create, can_update, can_delete, can_crud from %v WHERE role_id=$1 AND is_active=$2 ORDER BY service_category, service_id", crud.RoleTable)
+	permissions, err := crud.queryPermissions(roleScript, roleId, true)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading the role permissions: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v role permission(s) found", len(permissions)),
+		Value:   permissions,
+	})
+}
+
+// AssignRole method assigns the role to the user, by the UserRoleTable
+func (crud *Crud) AssignRole(userId string, roleId string) mcresponse.ResponseMessage {
+	if adminRes, ok := crud.adminCheck(); !ok {
+		return adminRes
+	}
+	if userId == "" || roleId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId and roleId are required",
+			Value:   nil,
+		})
+	}
+	var count int
+	countScript := fmt.Sprintf("SELECT COUNT(*) from %v WHERE user_id=$1 AND role_id=$2", crud.UserRoleTable)
+	if err := crud.AccessDb.QueryRowx(countScript, userId, roleId).Scan(&count); err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading the user-role: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	var err error
+	if count > 0 {
+		updateScript := fmt.Sprintf("UPDATE %v SET is_active=$1, updated_by=$2 WHERE user_id=$3 AND role_id=$4", crud.UserRoleTable)
+		_, err = crud.AccessDb.Exec(updateScript, true, crud.UserInfo.UserId, userId, roleId)
+	} else {
+		insertScript := fmt.Sprintf("INSERT INTO %v(user_id, role_id, created_by) VALUES ($1, $2, $3)", crud.UserRoleTable)
+		_, err = crud.AccessDb.Exec(insertScript, userId, roleId, crud.UserInfo.UserId)
+	}
+	if err != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error assigning the user-role: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	logMessage := crud.userRoleLog(CreateTask, userId, roleId)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("User-role assigned successfully [log-message: %v]", logMessage),
+		Value:   nil,
+	})
+}
+
+// UnassignRole method removes the role assignment of the user, from the UserRoleTable
+func (crud *Crud) UnassignRole(userId string, roleId string) mcresponse.ResponseMessage {
+	if adminRes, ok := crud.adminCheck(); !ok {
+		return adminRes
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1 AND role_id=$2", crud.UserRoleTable)
+	res, err := crud.AccessDb.Exec(delScript, userId, roleId)
+	if err != nil {
+		return mcresponse.GetResMessage("removeError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error removing the user-role: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected < 1 {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "User-role not found",
+			Value:   nil,
+		})
+	}
+	logMessage := crud.userRoleLog(DeleteTask, userId, roleId)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("User-role removed successfully [log-message: %v]", logMessage),
+		Value:   nil,
+	})
+}
+
+// EffectivePermissions method returns the merged permissions (EffectivePermissionType) of the active user roles
+// (profile and user-roles) and their ancestor roles, on the table and the table records. Admin-users are permitted all tasks
+func (crud *Crud) EffectivePermissions(userId string, tableName string) mcresponse.ResponseMessage {
+	// the logged-in user access, and the admin access for the other users' permissions
+	if accessRes := crud.CheckUserAccess(); accessRes.Code != "success" {
+		return accessRes
+	}
+	if userId != crud.UserInfo.UserId {
+		if adminRes, ok := crud.adminCheck(); !ok {
+			return adminRes
+		}
+	}
+	var (
+		isAdmin  bool
+		isActive bool
+	)
+	userScript := fmt.Sprintf("SELECT is_admin, is_active from %v WHERE id=$1", crud.UserTable)
+	if err := crud.AccessDb.QueryRowx(userScript, userId).Scan(&isAdmin, &isActive); err != nil {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("User information not found: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	roleIds, err := crud.userRoleIds(userId)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading the user roles: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	effective := EffectivePermissionType{
+		UserId:    userId,
+		TableName: tableName,
+		IsAdmin:   isAdmin && isActive,
+		RoleIds:   roleIds,
+		Records:   map[string]RoleServiceType{},
+	}
+	if effective.IsAdmin {
+		effective.Table = RoleServiceType{ServiceCategory: TableServiceCategory, CanRead: true, CanCreate: true, CanUpdate: true, CanDelete: true, CanCrud: true, TableAccessPermitted: true}
+	}
+	tableId, _ := crud.tableServiceId(tableName, false)
+	if isActive && len(roleIds) > 0 {
+		// the resolved (including the inherited) permissions of the user roles
+		permissions, pErr := crud.effectiveRoleServices(roleIds, nil)
+		if pErr != nil {
+			return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+				Message: fmt.Sprintf("Error reading the role permissions: %v", pErr.Error()),
+				Value:   nil,
+			})
+		}
+		for _, permission := range permissions {
+			if permission.ServiceId == tableId && permission.ServiceCategory == TableServiceCategory {
+				effective.Table = mergePermissions(effective.Table, permission)
+				effective.Table.TableAccessPermitted = true
+			} else if permission.ServiceCategory == tableName {
+				effective.Records[permission.ServiceId] = permission
+			}
+		}
+	}
+	effective.Table.ServiceId = tableId
+	effective.Table.ServiceCategory = TableServiceCategory
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Effective permissions computed successfully",
+		Value:   effective,
+	})
+}
+
+// userRoleIds returns the active role-ids of the user, by the profile and the user-roles, in sorted order
+func (crud *Crud) userRoleIds(userId string) ([]string, error) {
+	roleScript := fmt.Sprintf("SELECT role_id from %v WHERE user_id=$1 AND is_active=$2 UNION SELECT role_id from %v WHERE user_id=$3 AND is_active=$4",
+		crud.UserRoleTable, crud.ProfileTable)
+	var roleIds []string
+	if err := crud.AccessDb.Select(&roleIds, roleScript, userId, true, userId, true); err != nil {
+		return nil, err
+	}
+	var activeIds []string
+	for _, roleId := range roleIds {
+		if roleId != "" {
+			activeIds = append(activeIds, roleId)
+		}
+	}
+	sort.Strings(activeIds)
+	return activeIds, nil
+}
+
+// rolePermission returns the role permission on the service (table or record), and whether it exists
+func (crud *Crud) rolePermission(roleId string, serviceId string) (RoleServiceType, bool, error) {
+	roleScript := fmt.Sprintf("SELECT role_id, service_id, service_category, can_read, can_create, can_update, can_delete, can_crud from %v WHERE role_id=$1 AND service_id=$2", crud.RoleTable)
+	permissions, err := crud.queryPermissions(roleScript, roleId, serviceId)
+	if err != nil || len(permissions) < 1 {
+		return RoleServiceType{}, false, err
+	}
+	return permissions[0], true, nil
+}
+
+// queryPermissions returns the role-services (permissions) of the role query
+func (crud *Crud) queryPermissions(roleScript string, queryValues ...interface{}) ([]RoleServiceType, error) {
+	rows, err := crud.AccessDb.Queryx(roleScript, queryValues...)
+	if err != nil {
+		return nil, err
+	}
+	defer func(rows *sqlx.Rows) {
+		_ = rows.Close()
+	}(rows)
+	permissions := []RoleServiceType{}
+	for rows.Next() {
+		var permission RoleServiceType
+		if sErr := rows.Scan(&permission.RoleId, &permission.ServiceId, &permission.ServiceCategory, &permission.CanRead,
+			&permission.CanCreate, &permission.CanUpdate, &permission.CanDelete, &permission.CanCrud); sErr != nil {
+			return nil, sErr
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, rows.Err()
+}
+
+// mergePermissions returns the union of the permissions
+func mergePermissions(permission RoleServiceType, other RoleServiceType) RoleServiceType {
+	permission.ServiceId = other.ServiceId
+	permission.ServiceCategory = other.ServiceCategory
+	permission.RoleIds = append(permission.RoleIds, other.RoleId)
+	permission.CanRead = permission.CanRead || other.CanRead
+	permission.CanCreate = permission.CanCreate || other.CanCreate
+	permission.CanUpdate = permission.CanUpdate || other.CanUpdate
+	permission.CanDelete = permission.CanDelete || other.CanDelete
+	permission.CanCrud = permission.CanCrud || other.CanCrud ||
+		(permission.CanRead && permission.CanCreate && permission.CanUpdate && permission.CanDelete)
+	return permission
+}
+
+// roleAdminLog audits the role permission creation (CreateTask), change (UpdateTask) or removal (DeleteTask),
+// and returns the log-message
+func (crud *Crud) roleAdminLog(taskType string, current RoleServiceType, permission RoleServiceType) string {
+	auditInfo := AuditLogOptionsType{
+		TableName:  crud.RoleTable,
+		LogRecords: permission,
+	}
+	switch taskType {
+	case UpdateTask:
+		auditInfo.LogRecords = current
+		auditInfo.NewLogRecords = permission
+	case DeleteTask:
+		auditInfo.LogRecords = current
+	}
+	logRes, logErr := crud.TransLog.AuditLog(taskType, crud.UserInfo.UserId, auditInfo)
+	if logErr != nil {
+		return fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+	}
+	return fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+}
+
+// userRoleLog audits the user-role assignment (CreateTask) or removal (DeleteTask), and returns the log-message
+func (crud *Crud) userRoleLog(taskType string, userId string, roleId string) string {
+	auditInfo := AuditLogOptionsType{
+		TableName:  crud.UserRoleTable,
+		LogRecords: map[string]interface{}{"userId": userId, "roleId": roleId},
+	}
+	logRes, logErr := crud.TransLog.AuditLog(taskType, crud.UserInfo.UserId, auditInfo)
+	if logErr != nil {
+		return fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+	}
+	return fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+}
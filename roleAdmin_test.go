@@ -0,0 +1,89 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: role and permission administration test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"testing"
+
+	"github.com/abbeymart/mctest"
+)
+
+func TestRoleAdmin(t *testing.T) {
+	sqliteDb := accessTestDb(t, "roleAdmin.db")
+	admin := accessTestUser(t, sqliteDb, "admin", true, true)
+	editor := accessTestUser(t, sqliteDb, "editor", false, true)
+	newCrud := func(userInfo UserInfoType, recordIds ...string) *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, TableName: "items", UserInfo: userInfo, RecordIds: recordIds}, CrudOptionsType{})
+	}
+	countAudits := func() int {
+		var count int
+		_ = sqliteDb.QueryRowx("SELECT COUNT(*) FROM audits").Scan(&count)
+		return count
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should grant, list and revoke the role permissions, by the admin-user only, audited:",
+		TestFunc: func() {
+			res := newCrud(editor).Grant(PermissionParamsType{RoleId: "editors", TableName: "items", CanRead: true})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "non-admin grant response-code should be: unAuthorized")
+			res = newCrud(admin).Grant(PermissionParamsType{RoleId: "editors", TableName: "items", CanRead: true})
+			mctest.AssertEquals(t, res.Code, "success", "grant response-code should be: success")
+			res = newCrud(admin).Grant(PermissionParamsType{RoleId: "editors", TableName: "items", CanCreate: true, CanDelete: true})
+			mctest.AssertEquals(t, res.Code, "success", "additional grant response-code should be: success")
+			res = newCrud(admin).Grant(PermissionParamsType{RoleId: "editors", TableName: "items", RecordId: "r1", CanUpdate: true})
+			mctest.AssertEquals(t, res.Code, "success", "record grant response-code should be: success")
+			res = newCrud(admin).Revoke(PermissionParamsType{RoleId: "editors", TableName: "items", CanDelete: true})
+			mctest.AssertEquals(t, res.Code, "success", "revoke response-code should be: success")
+			permissions, _ := newCrud(admin).ListPermissions("editors").Value.([]RoleServiceType)
+			mctest.AssertEquals(t, len(permissions), 2, "role permissions should be: 2 (table and record)")
+			table := permissions[0]
+			if table.ServiceCategory != TableServiceCategory {
+				table = permissions[1]
+			}
+			mctest.AssertEquals(t, table.CanRead && table.CanCreate && !table.CanDelete, true, "table permissions should be: read and create")
+			mctest.AssertEquals(t, countAudits(), 4, "audit records should be: 4")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should revoke all the record permissions, audited as deleted, and refuse the role-table read error:",
+		TestFunc: func() {
+			res := newCrud(admin).Grant(PermissionParamsType{RoleId: "viewers", TableName: "items", RecordId: "r1", CanRead: true})
+			mctest.AssertEquals(t, res.Code, "success", "record grant response-code should be: success")
+			res = newCrud(admin).Revoke(PermissionParamsType{RoleId: "viewers", TableName: "items", RecordId: "r1"})
+			mctest.AssertEquals(t, res.Code, "success", "revoke-all response-code should be: success")
+			var deleteLogs int
+			_ = sqliteDb.QueryRowx("SELECT COUNT(*) FROM audits WHERE table_name=$1 AND log_type=$2", "roles", DeleteTask).Scan(&deleteLogs)
+			mctest.AssertEquals(t, deleteLogs, 1, "revoke-all delete audit records should be: 1")
+			res = newCrud(admin).Revoke(PermissionParamsType{RoleId: "viewers", TableName: "items", RecordId: "r1"})
+			mctest.AssertEquals(t, res.Code, "notFound", "revoked permission response-code should be: notFound")
+			crud := newCrud(admin)
+			crud.RoleTable = "missing_roles"
+			res = crud.Revoke(PermissionParamsType{RoleId: "viewers", TableName: "items", CanRead: true})
+			mctest.AssertEquals(t, res.Code, "readError", "missing role-table response-code should be: readError")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should assign the user-roles, and enforce the effective permissions:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, newCrud(editor).TaskPermissionById(ReadTask).Code, "unAuthorized", "unassigned read response-code should be: unAuthorized")
+			mctest.AssertEquals(t, newCrud(admin).AssignRole(editor.UserId, "editors").Code, "success", "assign-role response-code should be: success")
+			mctest.AssertEquals(t, newCrud(editor).TaskPermissionById(ReadTask).Code, "success", "table read response-code should be: success")
+			mctest.AssertEquals(t, newCrud(editor, "r1").TaskPermissionById(UpdateTask).Code, "success", "record update response-code should be: success")
+			mctest.AssertEquals(t, newCrud(editor, "r1").TaskPermissionById(DeleteTask).Code, "unAuthorized", "record delete response-code should be: unAuthorized")
+			res := newCrud(editor).EffectivePermissions(editor.UserId, "items")
+			effective, _ := res.Value.(EffectivePermissionType)
+			mctest.AssertEquals(t, res.Code, "success", "effective permissions response-code should be: success")
+			mctest.AssertEquals(t, effective.Table.CanRead && effective.Table.CanCreate && !effective.Table.CanUpdate, true, "effective table permissions should be: read and create")
+			mctest.AssertEquals(t, effective.Records["r1"].CanUpdate, true, "effective record r1 update should be: true")
+			mctest.AssertEquals(t, newCrud(editor).EffectivePermissions(admin.UserId, "items").Code, "unAuthorized", "other user effective permissions response-code should be: unAuthorized")
+			notLoggedIn := UserInfoType{UserId: editor.UserId, LoginName: editor.LoginName, Token: "invalid-token"}
+			mctest.AssertEquals(t, newCrud(notLoggedIn).EffectivePermissions(editor.UserId, "items").Code, "unAuthorized", "not logged-in user effective permissions response-code should be: unAuthorized")
+			mctest.AssertEquals(t, newCrud(admin).UnassignRole(editor.UserId, "editors").Code, "success", "unassign-role response-code should be: success")
+			mctest.AssertEquals(t, newCrud(editor).TaskPermissionById(ReadTask).Code, "unAuthorized", "unassigned read response-code should be: unAuthorized")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,234 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: hierarchical roles: permissions inherited from the parent roles, resolved and cached per role
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/abbeymart/mcresponse"
+)
+
+// RoleParentModel is the built-in role-parents (role hierarchy) table model: the role inherits the parent permissions
+type RoleParentModel struct {
+	BaseModelType
+	RoleId   string `json:"roleId" db:"role_id" ddl:"unique:role_parent"`
+	ParentId string `json:"parentId" db:"parent_id" ddl:"unique:role_parent"`
+}
+
+// AddRoleParent method sets the parent of the role, by the admin-user: the role inherits the parent (and ancestors)
+// permissions. Cyclic hierarchies are refused
+func (crud *Crud) AddRoleParent(roleId string, parentId string) mcresponse.ResponseMessage {
+	if adminRes, ok := crud.adminCheck(); !ok {
+		return adminRes
+	}
+	if roleId == "" || parentId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "roleId and parentId are required",
+			Value:   nil,
+		})
+	}
+	// the role must not be the parent, or one of the parent ancestors
+	ancestors, err := crud.roleAncestors(parentId)
+	if err != nil {
+		return mcresponse.GetResMessage("readError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error reading the role hierarchy: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if roleId == parentId || ArrayStringContains(ancestors, roleId) {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Role hierarchy cycle: role(%v) is an ancestor of the parent role(%v)", roleId, parentId),
+			Value:   nil,
+		})
+	}
+	var count int
+	countScript := fmt.Sprintf("SELECT COUNT(*) from %v WHERE role_id=$1 AND parent_id=$2", crud.RoleParentTable)
+	if err = crud.AccessDb.QueryRowx(countScript, roleId, parentId).Scan(&count); err == nil && count < 1 {
+		insertScript := fmt.Sprintf("INSERT INTO %v(role_id, parent_id, created_by) VALUES ($1, $2, $3)", crud.RoleParentTable)
+		_, err = crud.AccessDb.Exec(insertScript, roleId, parentId, crud.UserInfo.UserId)
+	}
+	if err != nil {
+		return mcresponse.GetResMessage("saveError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error setting the parent role: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	crud.invalidateRoles()
+	logMessage := crud.roleParentLog(CreateTask, roleId, parentId)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Parent role set successfully [log-message: %v]", logMessage),
+		Value:   nil,
+	})
+}
+
+// RemoveRoleParent method removes the parent of the role, by the admin-user
+func (crud *Crud) RemoveRoleParent(roleId string, parentId string) mcresponse.ResponseMessage {
+	if adminRes, ok := crud.adminCheck(); !ok {
+		return adminRes
+	}
+	delScript := fmt.Sprintf("DELETE FROM %v WHERE role_id=$1 AND parent_id=$2", crud.RoleParentTable)
+	res, err := crud.AccessDb.Exec(delScript, roleId, parentId)
+	if err != nil {
+		return mcresponse.GetResMessage("removeError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error removing the parent role: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	if rowsAffected, _ := res.RowsAffected(); rowsAffected < 1 {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "Parent role not found",
+			Value:   nil,
+		})
+	}
+	crud.invalidateRoles()
+	logMessage := crud.roleParentLog(DeleteTask, roleId, parentId)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Parent role removed successfully [log-message: %v]", logMessage),
+		Value:   nil,
+	})
+}
+
+// roleAncestors returns the ancestor role-ids of the role (parents, grand-parents...), in sorted order
+func (crud *Crud) roleAncestors(roleId string) ([]string, error) {
+	parentScript := fmt.Sprintf("SELECT parent_id from %v WHERE role_id=$1 AND is_active=$2", crud.RoleParentTable)
+	visited := map[string]bool{roleId: true}
+	var ancestors []string
+	pending := []string{roleId}
+	for len(pending) > 0 {
+		currentId := pending[0]
+		pending = pending[1:]
+		var parentIds []string
+		if err := crud.AccessDb.Select(&parentIds, parentScript, currentId, true); err != nil {
+			return nil, err
+		}
+		for _, parentId := range parentIds {
+			// visited once, also for the cycles created outside of AddRoleParent
+			if !visited[parentId] {
+				visited[parentId] = true
+				ancestors = append(ancestors, parentId)
+				pending = append(pending, parentId)
+			}
+		}
+	}
+	sort.Strings(ancestors)
+	return ancestors, nil
+}
+
+// roleCacheTag returns the resolved role permissions cache-tag (invalidation): the RoleTable,
+// and the tenant-id of the tenant-local access tables (TenantLocalAccess)
+func (crud *Crud) roleCacheTag() string {
+	if crud.TenantLocalAccess && crud.tenant.TenantId != "" {
+		return fmt.Sprintf("%v@%v", crud.RoleTable, crud.tenant.TenantId)
+	}
+	return crud.RoleTable
+}
+
+// roleCacheKey returns the resolved role permissions cache-key, by the AccessDb (db-connection) and the cache-tag
+func (crud *Crud) roleCacheKey(roleId string) string {
+	return fmt.Sprintf("role-permissions:%p:%v:%v", crud.AccessDb, crud.roleCacheTag(), roleId)
+}
+
+// resolvedRoleServices returns the role permissions, including the inherited (ancestors) permissions,
+// merged per service. Cached (CacheExpire), and tagged by the roleCacheTag for the invalidation
+func (crud *Crud) resolvedRoleServices(roleId string) ([]RoleServiceType, error) {
+	cacheKey := crud.roleCacheKey(roleId)
+	if cacheValue, ok := crud.Cache.Get(cacheKey); ok {
+		if roleServices, ok := cacheValue.([]RoleServiceType); ok {
+			return roleServices, nil
+		}
+	}
+	ancestors, err := crud.roleAncestors(roleId)
+	if err != nil {
+		return nil, err
+	}
+	roleIds := append([]string{roleId}, ancestors...)
+	var fieldValues []interface{}
+	var rolePlaceholders []string
+	for _, id := range roleIds {
+		fieldValues = append(fieldValues, id)
+		rolePlaceholders = append(rolePlaceholders, fmt.Sprintf("$%v", len(fieldValues)))
+	}
+	fieldValues = append(fieldValues, true)
+	roleScript := fmt.Sprintf("SELECT role_id, service_id, service_category, can_read, can_create, can_update, can_delete, can_crud from %v WHERE role_id IN (%v) AND is_active=$%v",
+		crud.RoleTable, strings.Join(rolePlaceholders, ", "), len(fieldValues))
+	permissions, err := crud.queryPermissions(roleScript, fieldValues...)
+	if err != nil {
+		return nil, err
+	}
+	roleServices := mergeRoleServices(permissions, nil)
+	for i := range roleServices {
+		roleServices[i].RoleId = roleId
+	}
+	_ = crud.Cache.Set(cacheKey, roleServices, time.Duration(crud.CacheExpire)*time.Second, crud.roleCacheTag())
+	return roleServices, nil
+}
+
+// effectiveRoleServices returns the resolved permissions of the roles, merged per service, for the specified
+// service items (all services, if not specified)
+func (crud *Crud) effectiveRoleServices(roleIds []string, serviceIds []string) ([]RoleServiceType, error) {
+	var permissions []RoleServiceType
+	for _, roleId := range roleIds {
+		if roleId == "" {
+			continue
+		}
+		roleServices, err := crud.resolvedRoleServices(roleId)
+		if err != nil {
+			return nil, errors.New(fmt.Sprintf("role(%v) permissions error: %v", roleId, err.Error()))
+		}
+		permissions = append(permissions, roleServices...)
+	}
+	return mergeRoleServices(permissions, serviceIds), nil
+}
+
+// mergeRoleServices merges the permissions per service-id, optionally filtered by the service items, in sorted order
+func mergeRoleServices(permissions []RoleServiceType, serviceIds []string) []RoleServiceType {
+	merged := map[string]RoleServiceType{}
+	var mergedIds []string
+	for _, permission := range permissions {
+		if serviceIds != nil && !ArrayStringContains(serviceIds, permission.ServiceId) {
+			continue
+		}
+		current, ok := merged[permission.ServiceId]
+		if !ok {
+			mergedIds = append(mergedIds, permission.ServiceId)
+		}
+		merged[permission.ServiceId] = mergePermissions(current, permission)
+	}
+	sort.Strings(mergedIds)
+	roleServices := []RoleServiceType{}
+	for _, serviceId := range mergedIds {
+		roleServices = append(roleServices, merged[serviceId])
+	}
+	return roleServices
+}
+
+// invalidateRoles evicts the resolved role permissions, locally, and broadcasts the invalidation (if InvalidationBus)
+func (crud *Crud) invalidateRoles() {
+	_ = crud.Cache.DeleteByTag(crud.roleCacheTag())
+	if crud.InvalidationBus != nil {
+		msg := InvalidationMessageType{TableName: crud.roleCacheTag(), Origin: instanceId}
+		if err := crud.InvalidationBus.Publish(msg); err != nil {
+			// the other instances' resolved permissions expire by CacheExpire
+			crud.handleError(errors.New(fmt.Sprintf("role permissions invalidation publish error: %v", err.Error())))
+		}
+	}
+}
+
+// roleParentLog audits the role-parent creation (CreateTask) or removal (DeleteTask), and returns the log-message
+func (crud *Crud) roleParentLog(taskType string, roleId string, parentId string) string {
+	auditInfo := AuditLogOptionsType{
+		TableName:  crud.RoleParentTable,
+		LogRecords: map[string]interface{}{"roleId": roleId, "parentId": parentId},
+	}
+	logRes, logErr := crud.TransLog.AuditLog(taskType, crud.UserInfo.UserId, auditInfo)
+	if logErr != nil {
+		return fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+	}
+	return fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+}
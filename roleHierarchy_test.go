@@ -0,0 +1,96 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: hierarchical roles test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"testing"
+
+	"github.com/abbeymart/mctest"
+)
+
+func TestRoleHierarchy(t *testing.T) {
+	sqliteDb := accessTestDb(t, "roleHierarchy.db")
+	cache := NewLRUCache(100)
+	admin := accessTestUser(t, sqliteDb, "admin", true, true)
+	manager := accessTestUser(t, sqliteDb, "manager", false, true)
+	newCrud := func(userInfo UserInfoType, recordIds ...string) *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, TableName: "items", UserInfo: userInfo, RecordIds: recordIds},
+			CrudOptionsType{Cache: cache})
+	}
+	adminCrud := newCrud(admin)
+	_ = adminCrud.Grant(PermissionParamsType{RoleId: "viewers", TableName: "items", CanRead: true})
+	_ = adminCrud.Grant(PermissionParamsType{RoleId: "editors", TableName: "items", CanUpdate: true})
+	_ = adminCrud.AssignRole(manager.UserId, "managers")
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should set the parent roles, and refuse the cyclic hierarchies:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, newCrud(manager).AddRoleParent("editors", "viewers").Code, "unAuthorized", "non-admin add-role-parent response-code should be: unAuthorized")
+			mctest.AssertEquals(t, adminCrud.AddRoleParent("editors", "viewers").Code, "success", "editors parent response-code should be: success")
+			mctest.AssertEquals(t, adminCrud.AddRoleParent("managers", "editors").Code, "success", "managers parent response-code should be: success")
+			mctest.AssertEquals(t, adminCrud.AddRoleParent("viewers", "managers").Code, "paramsError", "cyclic parent response-code should be: paramsError")
+			mctest.AssertEquals(t, adminCrud.AddRoleParent("viewers", "viewers").Code, "paramsError", "self parent response-code should be: paramsError")
+			ancestors, _ := adminCrud.roleAncestors("managers")
+			mctest.AssertEquals(t, len(ancestors), 2, "managers ancestors should be: 2 (editors, viewers)")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should resolve the inherited permissions, by TaskPermissionById and EffectivePermissions:",
+		TestFunc: func() {
+			mctest.AssertEquals(t, newCrud(manager).TaskPermissionById(ReadTask).Code, "success", "inherited read response-code should be: success")
+			mctest.AssertEquals(t, newCrud(manager, "r1").TaskPermissionById(UpdateTask).Code, "success", "inherited update response-code should be: success")
+			mctest.AssertEquals(t, newCrud(manager, "r1").TaskPermissionById(DeleteTask).Code, "unAuthorized", "delete response-code should be: unAuthorized")
+			effective, _ := newCrud(manager).EffectivePermissions(manager.UserId, "items").Value.(EffectivePermissionType)
+			mctest.AssertEquals(t, effective.Table.CanRead && effective.Table.CanUpdate && !effective.Table.CanDelete, true, "effective permissions should be: read and update")
+			_, cached := cache.Get(adminCrud.roleCacheKey("managers"))
+			mctest.AssertEquals(t, cached, true, "managers resolved permissions should be: cached")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should cache the resolved permissions, by the access db-connection and tenant:",
+		TestFunc: func() {
+			otherDb := accessTestDb(t, "otherRoleHierarchy.db")
+			otherAdmin := accessTestUser(t, otherDb, "admin", true, true)
+			otherManager := accessTestUser(t, otherDb, "manager", false, true)
+			newOtherCrud := func(userInfo UserInfoType) *Crud {
+				return NewCrud(CrudParamsType{AppDb: otherDb, TableName: "items", UserInfo: userInfo}, CrudOptionsType{Cache: cache})
+			}
+			_ = newOtherCrud(otherAdmin).AssignRole(otherManager.UserId, "managers")
+			mctest.AssertEquals(t, newOtherCrud(otherManager).TaskPermissionById(ReadTask).Code, "unAuthorized", "other access-db read response-code should be: unAuthorized")
+			mctest.AssertEquals(t, newCrud(manager).TaskPermissionById(ReadTask).Code, "success", "inherited read response-code should be: success")
+			tenantCrud := newCrud(admin)
+			tenantCrud.TenantLocalAccess = true
+			tenantCrud.tenant.TenantId = "acme"
+			mctest.AssertEquals(t, tenantCrud.roleCacheTag(), "roles@acme", "tenant-local access cache-tag should be: roles@acme")
+			mctest.AssertEquals(t, tenantCrud.roleCacheKey("managers") != adminCrud.roleCacheKey("managers"), true, "tenant-local access cache-key should be: different")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should invalidate the resolved permissions, on the role changes:",
+		TestFunc: func() {
+			_ = adminCrud.Grant(PermissionParamsType{RoleId: "viewers", TableName: "items", CanDelete: true})
+			_, cached := cache.Get(adminCrud.roleCacheKey("managers"))
+			mctest.AssertEquals(t, cached, false, "managers resolved permissions should be: evicted")
+			mctest.AssertEquals(t, newCrud(manager, "r1").TaskPermissionById(DeleteTask).Code, "success", "granted ancestor delete response-code should be: success")
+			mctest.AssertEquals(t, adminCrud.RemoveRoleParent("editors", "viewers").Code, "success", "remove-role-parent response-code should be: success")
+			mctest.AssertEquals(t, newCrud(manager).TaskPermissionById(ReadTask).Code, "unAuthorized", "removed inherited read response-code should be: unAuthorized")
+			mctest.AssertEquals(t, newCrud(manager, "r1").TaskPermissionById(UpdateTask).Code, "success", "editors update response-code should be: success")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should pass the role invalidation publish error to the OnError handler:",
+		TestFunc: func() {
+			var handledErrs []error
+			crud := NewCrud(CrudParamsType{AppDb: sqliteDb, TableName: "items", UserInfo: admin},
+				CrudOptionsType{Cache: cache, InvalidationBus: failingInvalidationBus{}, OnError: func(err error) {
+					handledErrs = append(handledErrs, err)
+				}})
+			mctest.AssertEquals(t, crud.AddRoleParent("editors", "viewers").Code, "success", "add-role-parent response-code should be: success")
+			mctest.AssertEquals(t, len(handledErrs), 1, "handled errors should be: 1")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -6,38 +6,70 @@ package mcdbcrud
 
 import (
 	"fmt"
-	"github.com/abbeymart/mccache"
 	"github.com/abbeymart/mcresponse"
 	"log"
 )
 
 // Create method creates new record(s)
 func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
-	// compute query
-	createQueryRes := ComputeCreateQuery(crud.TableName, recs)
-	if !createQueryRes.Ok {
-		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
-			Message: createQueryRes.Message,
-			Value:   nil,
-		})
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(CreateTask, recs, nil); !ok {
+		return tenantRes
 	}
 	//fmt.Printf("Query-info: %v \n", createQueryRes.CreateQueryObject.CreateQuery)
 	//fmt.Printf("query-values: %v\n", createQueryRes.CreateQueryObject.FieldValues)
 	// perform create/insert action, via transaction/copy-protocol:
-	tx, txErr := crud.AppDb.Beginx()
+	tx, txErr := crud.beginTx()
 	if txErr != nil {
 		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
 			Message: fmt.Sprintf("Error creating new record(s): %v", txErr.Error()),
 			Value:   nil,
 		})
 	}
+	// before-create hooks: may mutate the records, or veto the operation
+	hookParams := &HookParamsType{ActionParams: recs, Tx: tx}
+	if hookErr := crud.runHooks(BeforeCreateHook, hookParams); hookErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	recs = hookParams.ActionParams
+	// validate records, by the model-struct validate-tags, after the before-hooks mutations
+	if validateRes, ok := crud.validateRecords(recs, CreateTask); !ok {
+		_ = tx.Rollback()
+		return validateRes
+	}
+	// tenant and app_id filter check of the records, after the before-hooks mutations
+	if tenantRes, ok := crud.tenantCheck(CreateTask, recs, nil); !ok {
+		_ = tx.Rollback()
+		return tenantRes
+	}
+	// compute query
+	createQueryRes := ComputeCreateQuery(crud.TableName, recs)
+	if !createQueryRes.Ok {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: createQueryRes.Message,
+			Value:   nil,
+		})
+	}
 	// perform records' creation
 	insertCount := 0
 	var insertIds []string
 	var insertId string
-	// create new records by fieldValues
+	// create new records by fieldValues, via one prepared statement
+	createStmt := crud.prepareTx(tx, createQueryRes.CreateQueryObject.CreateQuery)
+	defer createStmt.Close()
 	for _, fValues := range createQueryRes.CreateQueryObject.FieldValues {
-		insertErr := tx.QueryRowx(createQueryRes.CreateQueryObject.CreateQuery, fValues...).Scan(&insertId)
+		insertErr := createStmt.QueryRowx(fValues...).Scan(&insertId)
 		if insertErr != nil {
 			if rErr := tx.Rollback(); rErr != nil {
 				log.Fatalf("Unable to Rollback: Check DB-driver: %v", rErr.Error())
@@ -50,6 +82,14 @@ func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
 		insertCount += 1
 		insertIds = append(insertIds, insertId)
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, CreateTask, insertIds, recs); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating new record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
 	// commit
 	txcErr := tx.Commit()
 	if txcErr != nil {
@@ -63,7 +103,7 @@ func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -79,6 +119,8 @@ func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
 			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
 		}
 	}
+	// after-create hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterCreateHook, &HookParamsType{ActionParams: recs, RecordIds: insertIds}, logMessage)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) creation completed successfully [log-message: %v]", logMessage),
@@ -93,6 +135,16 @@ func (crud *Crud) Create(recs ActionParamsType) mcresponse.ResponseMessage {
 
 // Update method updates existing record(s)
 func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, recs, recordIdsFromParams(recs)); !ok {
+		return tenantRes
+	}
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetByIds()
@@ -101,26 +153,59 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 			crud.CurrentRecords = value.Records
 		}
 	}
-	// create from updatedRecs (actionParams)
-	updateQueryRes := ComputeUpdateQuery(crud.TableName, recs)
-	if !updateQueryRes.Ok {
+	// perform update action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: updateQueryRes.Message,
+			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
 			Value:   nil,
 		})
 	}
-	// perform update action, via transaction:
-	tx, txErr := crud.AppDb.Begin()
-	if txErr != nil {
+	// before-update hooks: may mutate the records, or veto the operation
+	hookParams := &HookParamsType{ActionParams: recs, Records: crud.CurrentRecords, Tx: tx}
+	if hookErr := crud.runHooks(BeforeUpdateHook, hookParams); hookErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error updating record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	recs = hookParams.ActionParams
+	// validate records, by the model-struct validate-tags, after the before-hooks mutations
+	if validateRes, ok := crud.validateRecords(recs, UpdateTask); !ok {
+		_ = tx.Rollback()
+		return validateRes
+	}
+	// tenant and app_id filter check of the records, after the before-hooks mutations
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, recs, nil); !ok {
+		_ = tx.Rollback()
+		return tenantRes
+	}
+	// create from updatedRecs (actionParams)
+	updateQueryRes := ComputeUpdateQuery(crud.TableName, recs)
+	if !updateQueryRes.Ok {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: updateQueryRes.Message,
 			Value:   nil,
 		})
 	}
 	// perform records' updates
 	updateCount := 0
+	// one prepared statement per update-query (sql-text)
+	updateStmts := map[string]*txStmt{}
+	defer func() {
+		for _, updateStmt := range updateStmts {
+			updateStmt.Close()
+		}
+	}()
 	for _, upQuery := range updateQueryRes.UpdateQueryObjects {
-		_, updateErr := tx.Exec(upQuery.UpdateQuery, upQuery.FieldValues...)
+		updateStmt, ok := updateStmts[upQuery.UpdateQuery]
+		if !ok {
+			updateStmt = crud.prepareTx(tx, upQuery.UpdateQuery)
+			updateStmts[upQuery.UpdateQuery] = updateStmt
+		}
+		_, updateErr := updateStmt.Exec(upQuery.FieldValues...)
 		if updateErr != nil {
 			if rErr := tx.Rollback(); rErr != nil {
 				log.Fatalf("Unable to Rollback: Check DB-driver: %v", rErr.Error())
@@ -132,6 +217,14 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 		}
 		updateCount += 1
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, UpdateTask, recordIdsFromParams(recs), recs); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error updating record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
 	// commit
 	txcErr := tx.Commit()
 	if txcErr != nil {
@@ -142,7 +235,7 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -159,6 +252,8 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
 		}
 	}
+	// after-update hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterUpdateHook, &HookParamsType{ActionParams: recs, Records: crud.CurrentRecords}, logMessage)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) update completed successfully [log-message: %v]", logMessage),
@@ -174,6 +269,16 @@ func (crud *Crud) Update(recs ActionParamsType) mcresponse.ResponseMessage {
 
 // UpdateById method updates existing records (in batch) that met the specified record-id(s)
 func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, ActionParamsType{rec}, []string{id}); !ok {
+		return tenantRes
+	}
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetById(id)
@@ -182,23 +287,48 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 			crud.CurrentRecords = value.Records
 		}
 	}
-	// create from updatedRecs (actionParams)
-	updateQueryRes := ComputeUpdateQueryById(crud.TableName, rec, id)
-	if !updateQueryRes.Ok {
+	// perform update action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: updateQueryRes.Message,
+			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
 			Value:   nil,
 		})
 	}
-	// perform update action, via transaction:
-	tx, txErr := crud.AppDb.Begin()
-	if txErr != nil {
+	// before-update hooks: may mutate the records, or veto the operation
+	hookParams := &HookParamsType{ActionParams: ActionParamsType{rec}, Records: crud.CurrentRecords, Tx: tx}
+	if hookErr := crud.runHooks(BeforeUpdateHook, hookParams); hookErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error updating record(s): %v", hookErr.Error()),
 			Value:   nil,
 		})
 	}
-	_, updateErr := tx.Exec(updateQueryRes.UpdateQueryObject.UpdateQuery, updateQueryRes.UpdateQueryObject.FieldValues...)
+	if len(hookParams.ActionParams) > 0 {
+		rec = hookParams.ActionParams[0]
+	}
+	// validate records, by the model-struct validate-tags, after the before-hooks mutations
+	if validateRes, ok := crud.validateRecords(ActionParamsType{rec}, UpdateTask); !ok {
+		_ = tx.Rollback()
+		return validateRes
+	}
+	// tenant and app_id filter check of the records, after the before-hooks mutations
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, ActionParamsType{rec}, nil); !ok {
+		_ = tx.Rollback()
+		return tenantRes
+	}
+	// create from updatedRecs (actionParams)
+	updateQueryRes := ComputeUpdateQueryById(crud.TableName, rec, id)
+	if !updateQueryRes.Ok {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: updateQueryRes.Message,
+			Value:   nil,
+		})
+	}
+	updateStmt := crud.prepareTx(tx, updateQueryRes.UpdateQueryObject.UpdateQuery)
+	defer updateStmt.Close()
+	_, updateErr := updateStmt.Exec(updateQueryRes.UpdateQueryObject.FieldValues...)
 	if updateErr != nil {
 		if rErr := tx.Rollback(); rErr != nil {
 			log.Fatalf("Unable to Rollback: Check DB-driver: %v", rErr.Error())
@@ -208,6 +338,14 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 			Value:   nil,
 		})
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, UpdateTask, []string{id}, rec); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error updating record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
 	// commit
 	txcErr := tx.Commit()
 	if txcErr != nil {
@@ -218,7 +356,7 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -235,6 +373,8 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
 		}
 	}
+	// after-update hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterUpdateHook, &HookParamsType{ActionParams: ActionParamsType{rec}, RecordIds: []string{id}, Records: crud.CurrentRecords}, logMessage)
 	// response
 	rowsCount := 1
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
@@ -251,6 +391,16 @@ func (crud *Crud) UpdateById(rec ActionParamType, id string) mcresponse.Response
 
 // UpdateByIds method updates existing records (in batch) that met the specified record-id(s)
 func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, ActionParamsType{rec}, crud.RecordIds); !ok {
+		return tenantRes
+	}
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetByIds()
@@ -259,24 +409,49 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 			crud.CurrentRecords = value.Records
 		}
 	}
-	// create from updatedRecs (actionParams)
-	updateQueryRes := ComputeUpdateQueryByIds(crud.TableName, rec, crud.RecordIds)
-	if !updateQueryRes.Ok {
+	// perform update action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: updateQueryRes.Message,
+			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
 			Value:   nil,
 		})
 	}
-	// perform update action, via transaction:
-	tx, txErr := crud.AppDb.Begin()
-	if txErr != nil {
+	// before-update hooks: may mutate the records, or veto the operation
+	hookParams := &HookParamsType{ActionParams: ActionParamsType{rec}, Records: crud.CurrentRecords, Tx: tx}
+	if hookErr := crud.runHooks(BeforeUpdateHook, hookParams); hookErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error updating record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	if len(hookParams.ActionParams) > 0 {
+		rec = hookParams.ActionParams[0]
+	}
+	// validate records, by the model-struct validate-tags, after the before-hooks mutations
+	if validateRes, ok := crud.validateRecords(ActionParamsType{rec}, UpdateTask); !ok {
+		_ = tx.Rollback()
+		return validateRes
+	}
+	// tenant and app_id filter check of the records, after the before-hooks mutations
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, ActionParamsType{rec}, nil); !ok {
+		_ = tx.Rollback()
+		return tenantRes
+	}
+	// create from updatedRecs (actionParams)
+	updateQueryRes := ComputeUpdateQueryByIds(crud.TableName, rec, crud.RecordIds)
+	if !updateQueryRes.Ok {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: updateQueryRes.Message,
 			Value:   nil,
 		})
 	}
 	updateCount := 0
-	_, updateErr := tx.Exec(updateQueryRes.UpdateQueryObject.UpdateQuery, updateQueryRes.UpdateQueryObject.FieldValues...)
+	updateStmt := crud.prepareTx(tx, updateQueryRes.UpdateQueryObject.UpdateQuery)
+	defer updateStmt.Close()
+	_, updateErr := updateStmt.Exec(updateQueryRes.UpdateQueryObject.FieldValues...)
 	if updateErr != nil {
 		if rErr := tx.Rollback(); rErr != nil {
 			log.Fatalf("Unable to Rollback: Check DB-driver: %v", rErr.Error())
@@ -286,6 +461,14 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 			Value:   nil,
 		})
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, UpdateTask, crud.RecordIds, rec); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error updating record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
 	// commit
 	txcErr := tx.Commit()
 	if txcErr != nil {
@@ -302,7 +485,7 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 	//		updateCount += len(crud.RecordIds)
 	//	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -319,6 +502,8 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 			logMessage = fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
 		}
 	}
+	// after-update hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterUpdateHook, &HookParamsType{ActionParams: ActionParamsType{rec}, Records: crud.CurrentRecords}, logMessage)
 	// response
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) update completed successfully [log-message: %v]", logMessage),
@@ -334,6 +519,16 @@ func (crud *Crud) UpdateByIds(rec ActionParamType) mcresponse.ResponseMessage {
 
 // UpdateByParam method updates existing records (in batch) that met the specified query-params or where conditions
 func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage {
+	// read-your-writes: the current-records and subsequent reads of the crud-instance, from the primary
+	crud.primaryRead = true
+	// strict-schema check: the model/table drift is refused
+	if schemaRes, ok := crud.schemaCheck(); !ok {
+		return schemaRes
+	}
+	// tenant and app_id filter check: cross-tenant/app records and ids are refused
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, ActionParamsType{rec}, nil); !ok {
+		return tenantRes
+	}
 	// include audit-log feature
 	if crud.LogUpdate || crud.LogCrud {
 		getRes := crud.GetByParam()
@@ -342,25 +537,60 @@ func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage
 			crud.CurrentRecords = value.Records
 		}
 	}
+	// perform update action, via transaction:
+	tx, txErr := crud.beginTx()
+	if txErr != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Value:   nil,
+		})
+	}
+	// before-update hooks: may mutate the records, or veto the operation
+	hookParams := &HookParamsType{ActionParams: ActionParamsType{rec}, Records: crud.CurrentRecords, Tx: tx}
+	if hookErr := crud.runHooks(BeforeUpdateHook, hookParams); hookErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error updating record(s): %v", hookErr.Error()),
+			Value:   nil,
+		})
+	}
+	if len(hookParams.ActionParams) > 0 {
+		rec = hookParams.ActionParams[0]
+	}
+	// validate records, by the model-struct validate-tags, after the before-hooks mutations
+	if validateRes, ok := crud.validateRecords(ActionParamsType{rec}, UpdateTask); !ok {
+		_ = tx.Rollback()
+		return validateRes
+	}
+	// tenant and app_id filter check of the records, after the before-hooks mutations
+	if tenantRes, ok := crud.tenantCheck(UpdateTask, ActionParamsType{rec}, nil); !ok {
+		_ = tx.Rollback()
+		return tenantRes
+	}
 	// create from updatedRecs (actionParams)
 	updateQueryRes := ComputeUpdateQueryByParam(crud.TableName, rec, crud.QueryParams)
 	//fmt.Printf("\n\nUpdate-by-Params-query-object: %#v\n\n", updateQueryRes)
 	if !updateQueryRes.Ok {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
 			Message: updateQueryRes.Message,
 			Value:   nil,
 		})
 	}
-	// perform update action, via transaction:
-	tx, txErr := crud.AppDb.Begin()
-	if txErr != nil {
+	// outbox change-event record-ids, within the transaction, prior to the update
+	whereRes := ComputeWhereQuery(crud.QueryParams, 1)
+	_, outboxIds, recsErr := crud.outboxRecords(tx, whereRes.WhereQueryObject.WhereQuery, whereRes.WhereQueryObject.FieldValues...)
+	if recsErr != nil {
+		_ = tx.Rollback()
 		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
-			Message: fmt.Sprintf("Error updating record(s): %v", txErr.Error()),
+			Message: fmt.Sprintf("Error updating record(s): %v", recsErr.Error()),
 			Value:   nil,
 		})
 	}
 	updateFieldValues := updateQueryRes.UpdateQueryObject.FieldValues
-	res, updateErr := tx.Exec(updateQueryRes.UpdateQueryObject.UpdateQuery, updateFieldValues...)
+	updateStmt := crud.prepareTx(tx, updateQueryRes.UpdateQueryObject.UpdateQuery)
+	defer updateStmt.Close()
+	res, updateErr := updateStmt.Exec(updateFieldValues...)
 	if updateErr != nil {
 		if rErr := tx.Rollback(); rErr != nil {
 			log.Fatalf("Unable to Rollback: Check DB-driver: %v", rErr.Error())
@@ -370,6 +600,14 @@ func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage
 			Value:   nil,
 		})
 	}
+	// outbox change-event, within the transaction
+	if outboxErr := crud.writeOutbox(tx, UpdateTask, outboxIds, map[string]interface{}{"queryParams": crud.QueryParams, "record": rec}); outboxErr != nil {
+		_ = tx.Rollback()
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error updating record(s): %v", outboxErr.Error()),
+			Value:   nil,
+		})
+	}
 	// commit
 	txcErr := tx.Commit()
 	if txcErr != nil {
@@ -380,7 +618,7 @@ func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage
 		})
 	}
 	// delete cache
-	_ = mccache.DeleteHashCache(crud.CacheKey, crud.TableName, "hash")
+	crud.invalidateCache()
 	// perform audit-log
 	logMessage := ""
 	logRes := mcresponse.ResponseMessage{}
@@ -402,6 +640,8 @@ func (crud *Crud) UpdateByParam(rec ActionParamType) mcresponse.ResponseMessage
 	if rcErr != nil {
 		rowsCount = 0
 	}
+	// after-update hooks, once the transaction has committed
+	logMessage = crud.runAfterHooks(AfterUpdateHook, &HookParamsType{ActionParams: ActionParamsType{rec}, Records: crud.CurrentRecords}, logMessage)
 	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
 		Message: fmt.Sprintf("Record(s) update completed successfully [log-message: %v]", logMessage),
 		Value: CrudResultType{
@@ -0,0 +1,258 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: schema introspection (table-columns) and model/table drift detection
+
+package mcdbcrud
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+)
+
+// TableColumnType describes the table-column, as read from the database schema
+type TableColumnType struct {
+	ColumnName string  `json:"columnName"`
+	DataType   string  `json:"dataType"`
+	Nullable   bool    `json:"nullable"`
+	Default    *string `json:"default"`
+}
+
+// ColumnMismatchType describes the model/table column mismatch
+type ColumnMismatchType struct {
+	ColumnName  string `json:"columnName"`
+	ModelValue  string `json:"modelValue"`
+	ColumnValue string `json:"columnValue"`
+}
+
+// SchemaDriftType reports the differences between the model-struct and the table-columns
+type SchemaDriftType struct {
+	TableName          string               `json:"tableName"`
+	MissingColumns     []string             `json:"missingColumns"` // model fields without table-columns
+	ExtraColumns       []string             `json:"extraColumns"`   // table-columns without model fields
+	TypeMismatches     []ColumnMismatchType `json:"typeMismatches"`
+	NullableMismatches []ColumnMismatchType `json:"nullableMismatches"`
+}
+
+// HasDrift returns true if the model-struct and the table-columns differ
+func (drift SchemaDriftType) HasDrift() bool {
+	return len(drift.MissingColumns) > 0 || len(drift.ExtraColumns) > 0 || len(drift.TypeMismatches) > 0 ||
+		len(drift.NullableMismatches) > 0
+}
+
+// String returns the drift summary
+func (drift SchemaDriftType) String() string {
+	if !drift.HasDrift() {
+		return fmt.Sprintf("table[%v]: no drift", drift.TableName)
+	}
+	var items []string
+	if len(drift.MissingColumns) > 0 {
+		items = append(items, fmt.Sprintf("missing columns: %v", strings.Join(drift.MissingColumns, ", ")))
+	}
+	if len(drift.ExtraColumns) > 0 {
+		items = append(items, fmt.Sprintf("extra columns: %v", strings.Join(drift.ExtraColumns, ", ")))
+	}
+	for _, item := range drift.TypeMismatches {
+		items = append(items, fmt.Sprintf("type mismatch[%v]: model %v, table %v", item.ColumnName, item.ModelValue, item.ColumnValue))
+	}
+	for _, item := range drift.NullableMismatches {
+		items = append(items, fmt.Sprintf("nullable mismatch[%v]: model %v, table %v", item.ColumnName, item.ModelValue, item.ColumnValue))
+	}
+	return fmt.Sprintf("table[%v]: %v", drift.TableName, strings.Join(items, " | "))
+}
+
+// TableColumns returns the table-columns, in ordinal order, from information_schema (postgres, mysql, mariadb)
+// or PRAGMA table_info (sqlite3). The postgres table-name may be schema-qualified (schema.table)
+func TableColumns(appDb *sqlx.DB, tableName string, dbType string) ([]TableColumnType, error) {
+	if appDb == nil {
+		return nil, errors.New("db-connection is required")
+	}
+	if tableName == "" {
+		return nil, errors.New("table-name is required")
+	}
+	var (
+		columns []TableColumnType
+		rows    *sqlx.Rows
+		err     error
+	)
+	switch dbType {
+	case "postgres":
+		schemaName, tName, ok := strings.Cut(tableName, ".")
+		if !ok {
+			rows, err = appDb.Queryx("SELECT column_name, data_type, is_nullable, column_default FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position", tableName)
+		} else {
+			rows, err = appDb.Queryx("SELECT column_name, data_type, is_nullable, column_default FROM information_schema.columns WHERE table_schema = $1 AND table_name = $2 ORDER BY ordinal_position", schemaName, tName)
+		}
+	case "mysql", "mariadb":
+		rows, err = appDb.Queryx("SELECT column_name, data_type, is_nullable, column_default FROM information_schema.columns WHERE table_schema = DATABASE() AND table_name = ? ORDER BY ordinal_position", tableName)
+	case "sqlite3":
+		rows, err = appDb.Queryx(fmt.Sprintf("PRAGMA table_info(%v)", tableName))
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown db-type(%v)", dbType))
+	}
+	if err != nil {
+		return nil, errors.New(fmt.Sprintf("error reading table[%v] columns: %v", tableName, err.Error()))
+	}
+	defer func(rows *sqlx.Rows) {
+		err := rows.Close()
+		if err != nil {
+
+		}
+	}(rows)
+	for rows.Next() {
+		var (
+			columnName, dataType string
+			isNullable           string
+			columnDefault        sql.NullString
+			scanErr              error
+			column               TableColumnType
+		)
+		if dbType == "sqlite3" {
+			var (
+				cid, notNull, pk int
+			)
+			scanErr = rows.Scan(&cid, &columnName, &dataType, &notNull, &columnDefault, &pk)
+			// sqlite3 primary-key columns are reported as nullable
+			column = TableColumnType{ColumnName: columnName, DataType: dataType, Nullable: notNull == 0 && pk == 0}
+		} else {
+			scanErr = rows.Scan(&columnName, &dataType, &isNullable, &columnDefault)
+			column = TableColumnType{ColumnName: columnName, DataType: dataType, Nullable: strings.ToUpper(isNullable) == "YES"}
+		}
+		if scanErr != nil {
+			return nil, errors.New(fmt.Sprintf("error reading table[%v] columns: %v", tableName, scanErr.Error()))
+		}
+		if columnDefault.Valid {
+			defaultValue := columnDefault.String
+			column.Default = &defaultValue
+		}
+		columns = append(columns, column)
+	}
+	if rowErr := rows.Err(); rowErr != nil {
+		return nil, errors.New(fmt.Sprintf("error reading table[%v] columns: %v", tableName, rowErr.Error()))
+	}
+	if len(columns) < 1 {
+		return nil, errors.New(fmt.Sprintf("table[%v] not found", tableName))
+	}
+	return columns, nil
+}
+
+// columnTypeFamily normalises the dialect column-type, for the model/table type comparison
+func columnTypeFamily(dataType string) string {
+	dataType = strings.ToLower(strings.TrimSpace(dataType))
+	switch {
+	case strings.HasPrefix(dataType, "tinyint"), strings.HasPrefix(dataType, "bool"):
+		return "boolean"
+	case strings.Contains(dataType, "int"), strings.Contains(dataType, "serial"):
+		return "integer"
+	case strings.HasPrefix(dataType, "numeric"), strings.HasPrefix(dataType, "decimal"):
+		return "numeric"
+	case strings.Contains(dataType, "real"), strings.Contains(dataType, "double"), strings.Contains(dataType, "float"):
+		return "float"
+	case strings.HasPrefix(dataType, "timestamp"), strings.HasPrefix(dataType, "datetime"):
+		return "timestamp"
+	case strings.HasPrefix(dataType, "json"):
+		return "json"
+	case strings.Contains(dataType, "blob"), strings.HasPrefix(dataType, "bytea"), strings.Contains(dataType, "binary"):
+		return "binary"
+	case strings.Contains(dataType, "char"), strings.Contains(dataType, "text"), strings.Contains(dataType, "clob"),
+		strings.HasPrefix(dataType, "uuid"):
+		return "text"
+	default:
+		return dataType
+	}
+}
+
+// CheckModelTable compares the model-struct (see ModelColumns) with the table-columns, and reports
+// the missing columns, extra columns, type mismatches and nullability mismatches
+func CheckModelTable(appDb *sqlx.DB, modelRef interface{}, tableName string, dbType string) (SchemaDriftType, error) {
+	drift := SchemaDriftType{TableName: tableName}
+	modelColumns, err := ModelColumns(modelRef, dbType)
+	if err != nil {
+		return drift, err
+	}
+	tableColumns, err := TableColumns(appDb, tableName, dbType)
+	if err != nil {
+		return drift, err
+	}
+	tableColumnMap := map[string]TableColumnType{}
+	for _, column := range tableColumns {
+		tableColumnMap[strings.ToLower(column.ColumnName)] = column
+	}
+	modelColumnMap := map[string]bool{}
+	for _, modelColumn := range modelColumns {
+		modelColumnMap[strings.ToLower(modelColumn.ColumnName)] = true
+		tableColumn, ok := tableColumnMap[strings.ToLower(modelColumn.ColumnName)]
+		if !ok {
+			drift.MissingColumns = append(drift.MissingColumns, modelColumn.ColumnName)
+			continue
+		}
+		modelType := modelColumn.ColumnType
+		if modelColumn.PrimaryKey && !modelColumn.CustomType {
+			modelType, _ = ddlPrimaryKeyType(modelColumn.GoKind, dbType)
+		}
+		if columnTypeFamily(modelType) != columnTypeFamily(tableColumn.DataType) {
+			drift.TypeMismatches = append(drift.TypeMismatches, ColumnMismatchType{
+				ColumnName:  modelColumn.ColumnName,
+				ModelValue:  modelType,
+				ColumnValue: tableColumn.DataType,
+			})
+		}
+		if modelColumn.Nullable != tableColumn.Nullable {
+			drift.NullableMismatches = append(drift.NullableMismatches, ColumnMismatchType{
+				ColumnName:  modelColumn.ColumnName,
+				ModelValue:  fmt.Sprintf("nullable=%v", modelColumn.Nullable),
+				ColumnValue: fmt.Sprintf("nullable=%v", tableColumn.Nullable),
+			})
+		}
+	}
+	for _, tableColumn := range tableColumns {
+		if !modelColumnMap[strings.ToLower(tableColumn.ColumnName)] {
+			drift.ExtraColumns = append(drift.ExtraColumns, tableColumn.ColumnName)
+		}
+	}
+	return drift, nil
+}
+
+// schemaChecks caches the strict-schema check result, by db-connection and table-name
+var schemaChecks sync.Map
+
+// checkStrictSchema validates the model-struct against the table, once per db-connection and table-name.
+// The check (query) errors, e.g. transient connection errors, are not cached
+func checkStrictSchema(appDb *sqlx.DB, modelRef interface{}, tableName string) error {
+	if appDb == nil || modelRef == nil || tableName == "" {
+		return nil
+	}
+	checkKey := fmt.Sprintf("%p-%v", appDb, tableName)
+	if checkRes, ok := schemaChecks.Load(checkKey); ok {
+		if checkRes == nil {
+			return nil
+		}
+		return checkRes.(error)
+	}
+	drift, err := CheckModelTable(appDb, modelRef, tableName, appDb.DriverName())
+	if err != nil {
+		return errors.New(fmt.Sprintf("strict-schema check error: %v", err.Error()))
+	}
+	var checkErr error
+	if drift.HasDrift() {
+		checkErr = errors.New(fmt.Sprintf("strict-schema model/table drift: %v", drift.String()))
+	}
+	schemaChecks.Store(checkKey, checkErr)
+	return checkErr
+}
+
+// schemaCheck returns the paramsError response of the strict-schema (NewCrud) model/table drift or check error, if any
+func (crud *Crud) schemaCheck() (mcresponse.ResponseMessage, bool) {
+	if crud.schemaErr != nil {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: crud.schemaErr.Error(),
+			Value:   nil,
+		}), false
+	}
+	return mcresponse.ResponseMessage{}, true
+}
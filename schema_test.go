@@ -0,0 +1,77 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: schema introspection and model/table drift test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestCheckModelTable(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "schema.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	_ = CreateTable(sqliteDb, AuditModel{}, AuditTable, "sqlite3")
+	_, _ = sqliteDb.Exec("CREATE TABLE drift_audits (id TEXT PRIMARY KEY, table_name INTEGER NOT NULL, log_records TEXT, new_log_records TEXT, log_type TEXT, log_at TIMESTAMP NOT NULL, extra_note TEXT)")
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should read the table-columns by PRAGMA table_info:",
+		TestFunc: func() {
+			columns, err := TableColumns(sqliteDb, AuditTable, "sqlite3")
+			mctest.AssertEquals(t, err, nil, "table-columns error should be: nil")
+			mctest.AssertEquals(t, len(columns), 7, "table-columns length should be: 7")
+			mctest.AssertEquals(t, columns[1].ColumnName, "table_name", "second column-name should be: table_name")
+			_, err = TableColumns(sqliteDb, "not_a_table", "sqlite3")
+			mctest.AssertEquals(t, err != nil, true, "missing table error should be: not nil")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should report no drift for the table created from the model:",
+		TestFunc: func() {
+			drift, err := CheckModelTable(sqliteDb, AuditModel{}, AuditTable, "sqlite3")
+			mctest.AssertEquals(t, err, nil, "check error should be: nil")
+			mctest.AssertEquals(t, drift.HasDrift(), false, "drift should be: false")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should report missing, extra, type and nullable drift:",
+		TestFunc: func() {
+			drift, err := CheckModelTable(sqliteDb, AuditModel{}, "drift_audits", "sqlite3")
+			mctest.AssertEquals(t, err, nil, "check error should be: nil")
+			mctest.AssertEquals(t, strings.Join(drift.MissingColumns, ","), "log_by", "missing columns should be: log_by")
+			mctest.AssertEquals(t, strings.Join(drift.ExtraColumns, ","), "extra_note", "extra columns should be: extra_note")
+			mctest.AssertEquals(t, len(drift.TypeMismatches), 1, "type mismatches length should be: 1")
+			mctest.AssertEquals(t, drift.TypeMismatches[0].ColumnName, "table_name", "type mismatch column should be: table_name")
+			mctest.AssertEquals(t, len(drift.NullableMismatches), 1, "nullable mismatches length should be: 1")
+			mctest.AssertEquals(t, drift.NullableMismatches[0].ColumnName, "log_type", "nullable mismatch column should be: log_type")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should refuse the crud-operations in NewCrud strict-schema mode, on model/table drift:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: AuditModel{}, TableName: "drift_audits"}, CrudOptionsType{StrictSchema: true})
+			mctest.AssertEquals(t, crud.Err() != nil, true, "strict-schema drift error should be: not nil")
+			mctest.AssertEquals(t, crud.GetAll().Code, "paramsError", "strict-schema drift read response-code should be: paramsError")
+			crud = NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: AuditModel{}, TableName: AuditTable}, CrudOptionsType{StrictSchema: true})
+			mctest.AssertEquals(t, crud.Err(), nil, "strict-schema crud error should be: nil")
+			// the check (query) errors are not cached
+			closedDb, _ := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "closed.db"))
+			_ = closedDb.Close()
+			checkErr := checkStrictSchema(closedDb, AuditModel{}, AuditTable)
+			_, cached := schemaChecks.Load(fmt.Sprintf("%p-%v", closedDb, AuditTable))
+			mctest.AssertEquals(t, checkErr != nil, true, "closed db-connection check error should be: not nil")
+			mctest.AssertEquals(t, cached, false, "closed db-connection check error cached should be: false")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,211 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: login/logout session management, by the access (login-token) table
+
+package mcdbcrud
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/abbeymart/mcresponse"
+)
+
+// DefaultLoginTimeout is the session expiry (secs), if CrudOptionsType.LoginTimeout is not specified
+const DefaultLoginTimeout = 3600
+
+// SessionType is the Login and Refresh response value: the access token and its expiry (milliseconds)
+type SessionType struct {
+	UserId    string `json:"userId"`
+	LoginName string `json:"loginName"`
+	Token     string `json:"token"`
+	Expire    int64  `json:"expire"`
+}
+
+// newAccessToken returns a securely random (256-bit), hex-encoded access token
+func newAccessToken() (string, error) {
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", errors.New(fmt.Sprintf("error generating the access token: %v", err.Error()))
+	}
+	return hex.EncodeToString(tokenBytes), nil
+}
+
+// sessionExpire returns the session expiry time, in milliseconds, by LoginTimeout (secs)
+func (crud *Crud) sessionExpire() int64 {
+	return time.Now().Add(time.Duration(crud.LoginTimeout)*time.Second).UnixNano() / int64(time.Millisecond)
+}
+
+// Login method issues a new session (access token) for the active UserInfo.UserId and LoginName.
+// The user credentials must be verified by the caller, prior to the Login
+func (crud *Crud) Login() mcresponse.ResponseMessage {
+	params := crud.UserInfo
+	if params.UserId == "" || params.LoginName == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId and loginName are required to login",
+			Value:   nil,
+		})
+	}
+	var userId string
+	userQuery := fmt.Sprintf("SELECT id from %v WHERE id=$1 AND is_active=$2", crud.UserTable)
+	if uErr := crud.AccessDb.QueryRow(userQuery, params.UserId, true).Scan(&userId); uErr != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("User information not found or is inactive: %v", uErr.Error()),
+			Value:   nil,
+		})
+	}
+	token, tErr := newAccessToken()
+	if tErr != nil {
+		return mcresponse.GetResMessage("serverError", mcresponse.ResponseMessageOptions{
+			Message: tErr.Error(),
+			Value:   nil,
+		})
+	}
+	session := SessionType{UserId: userId, LoginName: params.LoginName, Token: token, Expire: crud.sessionExpire()}
+	insertQuery := fmt.Sprintf("INSERT INTO %v(user_id, login_name, token, expire) VALUES ($1, $2, $3, $4)", crud.AccessTable)
+	if _, err := crud.AccessDb.Exec(insertQuery, session.UserId, session.LoginName, crud.storedAccessToken(session.Token), session.Expire); err != nil {
+		return mcresponse.GetResMessage("insertError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error creating the login session: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	logMessage := crud.sessionLog(LoginTask, session.UserId, session.LoginName)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Login completed successfully [log-message: %v]", logMessage),
+		Value:   session,
+	})
+}
+
+// Refresh method extends the expiry of the active session (UserInfo.Token), by LoginTimeout,
+// and replaces the session token with a new one, if rotate is true
+func (crud *Crud) Refresh(rotate bool) mcresponse.ResponseMessage {
+	params := crud.UserInfo
+	storedToken, expire, lErr := crud.lookupSession(params.UserId, params.Token)
+	if lErr != nil {
+		return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Access information not found. Login first: %v", lErr.Error()),
+			Value:   nil,
+		})
+	}
+	if (time.Now().Unix() * 1000) > expire {
+		return mcresponse.GetResMessage("tokenExpired", mcresponse.ResponseMessageOptions{
+			Message: "Access expired: please login to continue",
+			Value:   nil,
+		})
+	}
+	session := SessionType{UserId: params.UserId, LoginName: params.LoginName, Token: params.Token, Expire: crud.sessionExpire()}
+	if rotate {
+		token, tErr := newAccessToken()
+		if tErr != nil {
+			return mcresponse.GetResMessage("serverError", mcresponse.ResponseMessageOptions{
+				Message: tErr.Error(),
+				Value:   nil,
+			})
+		}
+		session.Token = token
+	}
+	updateQuery := fmt.Sprintf("UPDATE %v SET token=$1, expire=$2 WHERE user_id=$3 AND token=$4", crud.AccessTable)
+	// the legacy plaintext token is replaced by the hashed token (TokenSecret)
+	res, err := crud.AccessDb.Exec(updateQuery, crud.storedAccessToken(session.Token), session.Expire, params.UserId, storedToken)
+	if err == nil {
+		var rowsAffected int64
+		if rowsAffected, err = res.RowsAffected(); err == nil && rowsAffected < 1 {
+			// revoked concurrently
+			err = errors.New("session not found")
+		}
+	}
+	if err != nil {
+		return mcresponse.GetResMessage("updateError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error refreshing the login session: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: "Session refreshed successfully",
+		Value:   session,
+	})
+}
+
+// Logout method revokes the session (UserInfo.Token) of the UserInfo.UserId
+func (crud *Crud) Logout() mcresponse.ResponseMessage {
+	storedToken, _, err := crud.lookupSession(crud.UserInfo.UserId, crud.UserInfo.Token)
+	if err != nil {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Login session not found: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	delQuery := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1 AND token=$2", crud.AccessTable)
+	return crud.revokeSessions(delQuery, crud.UserInfo.UserId, storedToken)
+}
+
+// LogoutAll method revokes all the sessions of the UserInfo.UserId, e.g. on password change
+func (crud *Crud) LogoutAll() mcresponse.ResponseMessage {
+	delQuery := fmt.Sprintf("DELETE FROM %v WHERE user_id=$1", crud.AccessTable)
+	return crud.revokeSessions(delQuery, crud.UserInfo.UserId)
+}
+
+// revokeSessions deletes the sessions, by the delete-query, and audits the LogoutTask
+func (crud *Crud) revokeSessions(delQuery string, queryValues ...interface{}) mcresponse.ResponseMessage {
+	if crud.UserInfo.UserId == "" {
+		return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+			Message: "userId is required to logout",
+			Value:   nil,
+		})
+	}
+	res, err := crud.AccessDb.Exec(delQuery, queryValues...)
+	if err != nil {
+		return mcresponse.GetResMessage("removeError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error revoking the login session(s): %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected < 1 {
+		return mcresponse.GetResMessage("notFound", mcresponse.ResponseMessageOptions{
+			Message: "Login session(s) not found",
+			Value:   nil,
+		})
+	}
+	logMessage := crud.sessionLog(LogoutTask, crud.UserInfo.UserId, crud.UserInfo.LoginName)
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Logout completed successfully [log-message: %v]", logMessage),
+		Value:   rowsAffected,
+	})
+}
+
+// PurgeExpired method deletes all the expired sessions, and returns the number of deleted sessions
+func (crud *Crud) PurgeExpired() mcresponse.ResponseMessage {
+	delQuery := fmt.Sprintf("DELETE FROM %v WHERE expire < $1", crud.AccessTable)
+	res, err := crud.AccessDb.Exec(delQuery, time.Now().Unix()*1000)
+	if err != nil {
+		return mcresponse.GetResMessage("removeError", mcresponse.ResponseMessageOptions{
+			Message: fmt.Sprintf("Error purging the expired sessions: %v", err.Error()),
+			Value:   nil,
+		})
+	}
+	rowsAffected, _ := res.RowsAffected()
+	return mcresponse.GetResMessage("success", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("%v expired session(s) purged", rowsAffected),
+		Value:   rowsAffected,
+	})
+}
+
+// sessionLog audits the login/logout task (LogLogin, LogLogout or LogCrud), and returns the log-message
+func (crud *Crud) sessionLog(taskType string, userId string, loginName string) string {
+	if !(crud.LogCrud || (taskType == LoginTask && crud.LogLogin) || (taskType == LogoutTask && crud.LogLogout)) {
+		return ""
+	}
+	auditInfo := AuditLogOptionsType{
+		TableName:  crud.AccessTable,
+		LogRecords: map[string]interface{}{"userId": userId, "loginName": loginName},
+	}
+	logRes, logErr := crud.TransLog.AuditLog(taskType, userId, auditInfo)
+	if logErr != nil {
+		return fmt.Sprintf("Audit-log-error: %v", logErr.Error())
+	}
+	return fmt.Sprintf("Audit-log-code: %v | Message: %v", logRes.Code, logRes.Message)
+}
@@ -0,0 +1,83 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: login/logout session management test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/abbeymart/mctest"
+)
+
+func TestSession(t *testing.T) {
+	sqliteDb := accessTestDb(t, "session.db")
+	userId := accessTestUser(t, sqliteDb, "abbey", false, false).UserId
+	newCrud := func(token string) *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, TableName: "users",
+			UserInfo: UserInfoType{UserId: userId, LoginName: "abbey", Token: token}},
+			CrudOptionsType{LoginTimeout: 60, LogLogin: true, LogLogout: true})
+	}
+	countRecords := func(tableName string) int {
+		var count int
+		_ = sqliteDb.QueryRowx("SELECT COUNT(*) FROM " + tableName).Scan(&count)
+		return count
+	}
+	var session SessionType
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should login the active user, with a random token and expiry, and audit the login:",
+		TestFunc: func() {
+			res := newCrud("").Login()
+			mctest.AssertEquals(t, res.Code, "success", "login response-code should be: success")
+			session, _ = res.Value.(SessionType)
+			mctest.AssertEquals(t, len(session.Token), 64, "token length should be: 64")
+			expire := time.Now().Add(60*time.Second).Unix() * 1000
+			mctest.AssertEquals(t, session.Expire > expire-5000 && session.Expire <= expire+1000, true, "expire should be: now + LoginTimeout")
+			other, _ := newCrud("").Login().Value.(SessionType)
+			mctest.AssertEquals(t, other.Token != session.Token, true, "second login token should be: different")
+			mctest.AssertEquals(t, newCrud(session.Token).CheckLoginStatus().Code, "success", "login-status response-code should be: success")
+			mctest.AssertEquals(t, countRecords("audits"), 2, "login audit records should be: 2")
+			crud := newCrud("")
+			crud.UserInfo.UserId = "unknown"
+			mctest.AssertEquals(t, crud.Login().Code, "unAuthorized", "unknown user response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should refresh or rotate the session token:",
+		TestFunc: func() {
+			res := newCrud(session.Token).Refresh(false)
+			mctest.AssertEquals(t, res.Code, "success", "refresh response-code should be: success")
+			refreshed, _ := res.Value.(SessionType)
+			mctest.AssertEquals(t, refreshed.Token, session.Token, "refreshed token should be: unchanged")
+			res = newCrud(session.Token).Refresh(true)
+			mctest.AssertEquals(t, res.Code, "success", "rotate response-code should be: success")
+			rotated, _ := res.Value.(SessionType)
+			mctest.AssertEquals(t, rotated.Token != session.Token, true, "rotated token should be: different")
+			mctest.AssertEquals(t, newCrud(session.Token).CheckLoginStatus().Code, "unAuthorized", "replaced token response-code should be: unAuthorized")
+			session = rotated
+			mctest.AssertEquals(t, newCrud("invalid").Refresh(false).Code, "unAuthorized", "invalid token refresh response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should logout the session or all the user sessions, and purge the expired sessions:",
+		TestFunc: func() {
+			res := newCrud(session.Token).Logout()
+			mctest.AssertEquals(t, res.Code, "success", "logout response-code should be: success")
+			mctest.AssertEquals(t, newCrud(session.Token).CheckLoginStatus().Code, "unAuthorized", "logged-out token response-code should be: unAuthorized")
+			mctest.AssertEquals(t, newCrud(session.Token).Logout().Code, "notFound", "repeated logout response-code should be: notFound")
+			_ = newCrud("").Login()
+			res = newCrud("").LogoutAll()
+			mctest.AssertEquals(t, res.Code, "success", "logout-all response-code should be: success")
+			mctest.AssertEquals(t, res.Value, int64(2), "logout-all sessions should be: 2")
+			_, _ = sqliteDb.Exec("INSERT INTO accesses(user_id, login_name, token, expire) VALUES ($1, 'abbey', 'expired', 1)", userId)
+			_ = newCrud("").Login()
+			res = newCrud("").PurgeExpired()
+			mctest.AssertEquals(t, res.Value, int64(1), "purged sessions should be: 1")
+			mctest.AssertEquals(t, countRecords("accesses"), 1, "remaining sessions should be: 1")
+		},
+	})
+
+	mctest.PostTestResult()
+}
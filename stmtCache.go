@@ -0,0 +1,237 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: bounded prepared-statement cache (per db-connection), keyed by the generated sql-text
+
+package mcdbcrud
+
+import (
+	"container/list"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// StmtCacheOptionsType is the prepared-statement cache options, per db-connection
+type StmtCacheOptionsType struct {
+	MaxStatements int  // default: 100
+	Disabled      bool // e.g. for drivers or poolers (pgbouncer transaction-mode) without prepared-statements support
+}
+
+// stmtEntry is the cached prepared-statement, reference-counted, to close the evicted statement once released
+type stmtEntry struct {
+	query   string
+	stmt    *sqlx.Stmt
+	refs    int
+	evicted bool
+}
+
+// StmtCache is the least-recently-used prepared-statements cache of the db-connection
+type StmtCache struct {
+	db            *sqlx.DB
+	maxStatements int
+	disabled      bool
+	entries       *list.List
+	items         map[string]*list.Element
+	mutex         sync.Mutex
+}
+
+var stmtCaches sync.Map // *sqlx.DB => *StmtCache
+
+// ConfigureStmtCache sets the prepared-statement cache options of the db-connection, closing the cached statements
+func ConfigureStmtCache(db *sqlx.DB, options StmtCacheOptionsType) *StmtCache {
+	if options.MaxStatements <= 0 {
+		options.MaxStatements = 100
+	}
+	cache := &StmtCache{
+		db:            db,
+		maxStatements: options.MaxStatements,
+		disabled:      options.Disabled,
+		entries:       list.New(),
+		items:         map[string]*list.Element{},
+	}
+	if previous, loaded := stmtCaches.Swap(db, cache); loaded {
+		previous.(*StmtCache).Close()
+	}
+	return cache
+}
+
+// GetStmtCache returns the prepared-statement cache of the db-connection (default options, if not configured)
+func GetStmtCache(db *sqlx.DB) *StmtCache {
+	if cache, ok := stmtCaches.Load(db); ok {
+		return cache.(*StmtCache)
+	}
+	cache, _ := stmtCaches.LoadOrStore(db, &StmtCache{
+		db:            db,
+		maxStatements: 100,
+		entries:       list.New(),
+		items:         map[string]*list.Element{},
+	})
+	return cache.(*StmtCache)
+}
+
+// CloseStmtCache closes the cached statements of the db-connection, and removes its prepared-statement cache.
+// Call on closing the db-connection (DbRegistry.Close and CloseAll close the registered connections caches)
+func CloseStmtCache(db *sqlx.DB) {
+	if cache, loaded := stmtCaches.LoadAndDelete(db); loaded {
+		cache.(*StmtCache).Close()
+	}
+}
+
+// acquire returns the cached (or newly prepared) statement of the query, to be released after use
+func (c *StmtCache) acquire(query string) (*stmtEntry, error) {
+	c.mutex.Lock()
+	if element, ok := c.items[query]; ok {
+		entry := element.Value.(*stmtEntry)
+		entry.refs++
+		c.entries.MoveToFront(element)
+		c.mutex.Unlock()
+		return entry, nil
+	}
+	c.mutex.Unlock()
+	stmt, err := c.db.Preparex(query)
+	if err != nil {
+		return nil, err
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	// prepared concurrently
+	if element, ok := c.items[query]; ok {
+		_ = stmt.Close()
+		entry := element.Value.(*stmtEntry)
+		entry.refs++
+		c.entries.MoveToFront(element)
+		return entry, nil
+	}
+	entry := &stmtEntry{query: query, stmt: stmt, refs: 1}
+	c.items[query] = c.entries.PushFront(entry)
+	// evict the least-recently-used statements
+	for c.entries.Len() > c.maxStatements {
+		c.evict(c.entries.Back())
+	}
+	return entry, nil
+}
+
+// release releases the statement, and closes it, if evicted and no longer in use
+func (c *StmtCache) release(entry *stmtEntry) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	entry.refs--
+	if entry.evicted && entry.refs <= 0 {
+		_ = entry.stmt.Close()
+	}
+}
+
+// evict removes the list-element statement, and closes it, if not in use (lock held)
+func (c *StmtCache) evict(element *list.Element) {
+	entry := element.Value.(*stmtEntry)
+	c.entries.Remove(element)
+	delete(c.items, entry.query)
+	entry.evicted = true
+	if entry.refs <= 0 {
+		_ = entry.stmt.Close()
+	}
+}
+
+// Len returns the cached statements count
+func (c *StmtCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.entries.Len()
+}
+
+// Close closes and removes all the cached statements
+func (c *StmtCache) Close() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.entries.Len() > 0 {
+		c.evict(c.entries.Back())
+	}
+}
+
+// txStmt is the (cached) prepared-statement of the query, bound to the transaction,
+// or the unprepared query, if the statement cache is disabled or the statement preparation fails
+type txStmt struct {
+	tx    *sqlx.Tx
+	query string
+	stmt  *sqlx.Stmt
+	entry *stmtEntry
+	cache *StmtCache
+}
+
+// prepareTx returns the transaction statement of the query, prepared once and reused across the calls
+func (crud *Crud) prepareTx(tx *sqlx.Tx, query string) *txStmt {
+	txStatement := &txStmt{tx: tx, query: query}
+	if crud.DisablePreparedStatements {
+		return txStatement
+	}
+	cache := GetStmtCache(crud.AppDb)
+	if cache.disabled {
+		return txStatement
+	}
+	entry, err := cache.acquire(query)
+	if err != nil {
+		// transparent fallback to the unprepared query
+		return txStatement
+	}
+	txStatement.entry = entry
+	txStatement.cache = cache
+	txStatement.stmt = tx.Stmtx(entry.stmt)
+	return txStatement
+}
+
+// QueryRowx executes the query that returns at most one row
+func (s *txStmt) QueryRowx(args ...interface{}) *sqlx.Row {
+	if s.stmt != nil {
+		return s.stmt.QueryRowx(args...)
+	}
+	return s.tx.QueryRowx(s.query, args...)
+}
+
+// Exec executes the query, without returning rows. The RETURNING rows of the prepared statement are
+// read and closed, to release (reset) the statement before commit, e.g. sqlite3 "statements in progress"
+func (s *txStmt) Exec(args ...interface{}) (sql.Result, error) {
+	if s.stmt == nil {
+		return s.tx.Exec(s.query, args...)
+	}
+	if !strings.Contains(s.query, " RETURNING ") {
+		return s.stmt.Exec(args...)
+	}
+	rows, err := s.stmt.Queryx(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var rowsAffected int64
+	for rows.Next() {
+		rowsAffected++
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return returningResult(rowsAffected), nil
+}
+
+// returningResult is the sql.Result of the RETURNING rows
+type returningResult int64
+
+// LastInsertId is not supported, use the RETURNING rows
+func (r returningResult) LastInsertId() (int64, error) {
+	return 0, errors.New("LastInsertId is not supported by the RETURNING query")
+}
+
+// RowsAffected returns the number of RETURNING rows
+func (r returningResult) RowsAffected() (int64, error) {
+	return int64(r), nil
+}
+
+// Close closes the transaction statement, and releases the cached statement
+func (s *txStmt) Close() {
+	if s.stmt != nil {
+		_ = s.stmt.Close()
+		s.cache.release(s.entry)
+		s.stmt = nil
+	}
+}
@@ -0,0 +1,77 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: prepared-statement cache test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+func TestStmtCache(t *testing.T) {
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "stmtCache.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const stmtTable = "stmt_items"
+	_ = CreateTable(sqliteDb, hookTestModel{}, stmtTable, "sqlite3")
+	newCrud := func(options CrudOptionsType) *Crud {
+		return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: stmtTable}, options)
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should create the records, via one cached prepared statement:",
+		TestFunc: func() {
+			res := newCrud(CrudOptionsType{}).Create(ActionParamsType{{"name": "abc"}, {"name": "def"}, {"name": "ghi"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, GetStmtCache(sqliteDb).Len(), 1, "cached statements should be: 1")
+			res = newCrud(CrudOptionsType{}).Create(ActionParamsType{{"name": "jkl"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, GetStmtCache(sqliteDb).Len(), 1, "cached statements should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should evict the least-recently-used statements, by the size-bound:",
+		TestFunc: func() {
+			cache := ConfigureStmtCache(sqliteDb, StmtCacheOptionsType{MaxStatements: 1})
+			res := newCrud(CrudOptionsType{}).Create(ActionParamsType{{"name": "mno"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			res = newCrud(CrudOptionsType{}).UpdateById(ActionParamType{"name": "pqr"}, "1")
+			mctest.AssertEquals(t, res.Code, "success", "update response-code should be: success")
+			mctest.AssertEquals(t, cache.Len(), 1, "cached statements should be: 1")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should send the unprepared queries, if disabled:",
+		TestFunc: func() {
+			cache := ConfigureStmtCache(sqliteDb, StmtCacheOptionsType{})
+			res := newCrud(CrudOptionsType{DisablePreparedStatements: true}).Create(ActionParamsType{{"name": "stu"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, cache.Len(), 0, "cached statements should be: 0")
+			cache = ConfigureStmtCache(sqliteDb, StmtCacheOptionsType{Disabled: true})
+			res = newCrud(CrudOptionsType{}).Create(ActionParamsType{{"name": "vwx"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, cache.Len(), 0, "cached statements should be: 0")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should close and remove the db-connection statement cache:",
+		TestFunc: func() {
+			cache := ConfigureStmtCache(sqliteDb, StmtCacheOptionsType{})
+			res := newCrud(CrudOptionsType{}).Create(ActionParamsType{{"name": "yza"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, cache.Len(), 1, "cached statements should be: 1")
+			CloseStmtCache(sqliteDb)
+			_, cached := stmtCaches.Load(sqliteDb)
+			mctest.AssertEquals(t, cached, false, "db-connection statement cache should be: removed")
+			mctest.AssertEquals(t, cache.Len(), 0, "closed cache statements should be: 0")
+		},
+	})
+
+	mctest.PostTestResult()
+}
@@ -0,0 +1,276 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: multi-tenancy routing of the crud-operations, by tenant (AppParams.AppId or UserInfo)
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/abbeymart/mcresponse"
+	"github.com/jmoiron/sqlx"
+)
+
+// tenancy strategies
+const (
+	TenantDatabase    = "database"     // database-per-tenant: the tenant AppDb (and ReadReplicas)
+	TenantTablePrefix = "table-prefix" // table-per-tenant: the tenant TablePrefix + TableName, in the shared AppDb
+	TenantSharedTable = "shared-table" // shared-tables: the tenant records, by the app_id column
+	TenantSchema      = "schema"       // schema-per-tenant (postgres): the tenant Schema-qualified TableName, in the shared AppDb
+)
+
+// TenantStoreType is the tenant data-store
+type TenantStoreType struct {
+	TenantId     string
+	AppDb        *sqlx.DB    // database-per-tenant
+	ReadReplicas *ReplicaSet // database-per-tenant read-replicas, optional
+	TablePrefix  string      // table-per-tenant, e.g. "acme_"
+	Schema       string      // schema-per-tenant, e.g. "acme"
+}
+
+// TenantIdFunc returns the tenant-id of the crud-operation
+type TenantIdFunc func(appParams AppParamsType, userInfo UserInfoType) string
+
+// TenancyOptionsType is the multi-tenancy options
+type TenancyOptionsType struct {
+	Strategy     string       // TenantDatabase, TenantTablePrefix, TenantSharedTable or TenantSchema
+	TenantIdFunc TenantIdFunc // default: AppParams.AppId
+}
+
+// Tenancy is the tenants registry and routing strategy (safe for concurrent use)
+type Tenancy struct {
+	strategy     string
+	tenantIdFunc TenantIdFunc
+	stores       map[string]TenantStoreType
+	mutex        sync.RWMutex
+}
+
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// NewTenancy constructor returns the multi-tenancy instance, by strategy
+func NewTenancy(options TenancyOptionsType) (*Tenancy, error) {
+	switch options.Strategy {
+	case TenantDatabase, TenantTablePrefix, TenantSharedTable, TenantSchema:
+	default:
+		return nil, errors.New(fmt.Sprintf("unknown tenancy strategy(%v)", options.Strategy))
+	}
+	tenantIdFunc := options.TenantIdFunc
+	if tenantIdFunc == nil {
+		tenantIdFunc = func(appParams AppParamsType, userInfo UserInfoType) string {
+			return appParams.AppId
+		}
+	}
+	return &Tenancy{
+		strategy:     options.Strategy,
+		tenantIdFunc: tenantIdFunc,
+		stores:       map[string]TenantStoreType{},
+	}, nil
+}
+
+// Strategy returns the tenancy strategy
+func (t *Tenancy) Strategy() string {
+	return t.strategy
+}
+
+// Register registers the tenant data-store: AppDb is required for the database-per-tenant strategy,
+// TablePrefix (identifier) for the table-per-tenant strategy, and Schema (identifier) for the schema-per-tenant strategy
+func (t *Tenancy) Register(store TenantStoreType) error {
+	if store.TenantId == "" {
+		return errors.New("tenant-id is required")
+	}
+	switch t.strategy {
+	case TenantDatabase:
+		if store.AppDb == nil {
+			return errors.New(fmt.Sprintf("tenant[%v] app-db is required", store.TenantId))
+		}
+	case TenantTablePrefix:
+		if !identifierRegex.MatchString(store.TablePrefix) {
+			return errors.New(fmt.Sprintf("tenant[%v] invalid table-prefix(%v)", store.TenantId, store.TablePrefix))
+		}
+	case TenantSchema:
+		if !identifierRegex.MatchString(store.Schema) {
+			return errors.New(fmt.Sprintf("tenant[%v] invalid schema(%v)", store.TenantId, store.Schema))
+		}
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.stores[store.TenantId] = store
+	return nil
+}
+
+// OpenTenant opens (by DefaultDbRegistry, as "tenant:<tenantId>") and registers the tenant database
+func (t *Tenancy) OpenTenant(tenantId string, dbConfig DbConfig) error {
+	appDb, err := DefaultDbRegistry.Open("tenant:"+tenantId, dbConfig)
+	if err != nil {
+		return err
+	}
+	return t.Register(TenantStoreType{TenantId: tenantId, AppDb: appDb})
+}
+
+// Remove removes the registered tenant data-store
+func (t *Tenancy) Remove(tenantId string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	delete(t.stores, tenantId)
+}
+
+// Tenants returns the registered tenant-ids, in sorted order
+func (t *Tenancy) Tenants() []string {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	var tenantIds []string
+	for tenantId := range t.stores {
+		tenantIds = append(tenantIds, tenantId)
+	}
+	sort.Strings(tenantIds)
+	return tenantIds
+}
+
+// Resolve returns the tenant data-store of the crud-operation. The AppParams.AppId, if specified,
+// must match the resolved tenant-id (e.g. by UserInfo). Shared-table tenants need no registration
+func (t *Tenancy) Resolve(appParams AppParamsType, userInfo UserInfoType) (TenantStoreType, error) {
+	tenantId := t.tenantIdFunc(appParams, userInfo)
+	if tenantId == "" {
+		return TenantStoreType{}, errors.New("tenant-id (appId) is required")
+	}
+	if appParams.AppId != "" && appParams.AppId != tenantId {
+		return TenantStoreType{}, errors.New(fmt.Sprintf("cross-tenant appId(%v) is not permitted", appParams.AppId))
+	}
+	t.mutex.RLock()
+	store, ok := t.stores[tenantId]
+	t.mutex.RUnlock()
+	if ok {
+		return store, nil
+	}
+	if t.strategy == TenantSharedTable {
+		return TenantStoreType{TenantId: tenantId}, nil
+	}
+	return TenantStoreType{}, errors.New(fmt.Sprintf("unknown tenant(%v)", tenantId))
+}
+
+// applyTenant resolves and applies the tenant data-store (or the per-call Schema) to the crud-instance:
+// AppDb and ReadReplicas (database), the TableName prefix or schema-qualifier, and the tenant-local audit
+// and access databases or tables (TenantLocalAudit, TenantLocalAccess)
+func (crud *Crud) applyTenant() {
+	store := TenantStoreType{Schema: crud.Schema}
+	strategy := TenantSchema
+	if crud.Tenancy != nil {
+		tenantStore, err := crud.Tenancy.Resolve(crud.AppParams, crud.UserInfo)
+		if err != nil {
+			crud.tenantErr = err
+			return
+		}
+		if crud.Schema != "" && crud.Schema != tenantStore.Schema {
+			crud.tenantErr = errors.New(fmt.Sprintf("cross-tenant schema(%v) is not permitted", crud.Schema))
+			return
+		}
+		store = tenantStore
+		strategy = crud.Tenancy.Strategy()
+	}
+	if store.Schema != "" && !identifierRegex.MatchString(store.Schema) {
+		crud.tenantErr = errors.New(fmt.Sprintf("invalid schema(%v)", store.Schema))
+		return
+	}
+	crud.tenant = store
+	qualify := func(tableName string) string {
+		return tableName
+	}
+	switch strategy {
+	case TenantDatabase:
+		crud.AppDb = store.AppDb
+		crud.ReadReplicas = store.ReadReplicas
+		if crud.TenantLocalAudit {
+			crud.AuditDb = store.AppDb
+		}
+		if crud.TenantLocalAccess {
+			crud.AccessDb = store.AppDb
+		}
+	case TenantTablePrefix:
+		qualify = func(tableName string) string {
+			return store.TablePrefix + tableName
+		}
+	case TenantSchema:
+		if store.Schema != "" {
+			qualify = func(tableName string) string {
+				return store.Schema + "." + tableName
+			}
+		}
+	}
+	crud.tenantQualify = qualify
+	crud.TableName = qualify(crud.TableName)
+	if crud.TenantLocalAudit {
+		crud.AuditTable = qualify(crud.AuditTable)
+	}
+	if crud.TenantLocalAccess {
+		crud.AccessTable = qualify(crud.AccessTable)
+		crud.UserTable = qualify(crud.UserTable)
+		crud.RoleTable = qualify(crud.RoleTable)
+		crud.UserRoleTable = qualify(crud.UserRoleTable)
+		crud.RoleParentTable = qualify(crud.RoleParentTable)
+		crud.VerifyTable = qualify(crud.VerifyTable)
+		crud.ProfileTable = qualify(crud.ProfileTable)
+		crud.ServiceTable = qualify(crud.ServiceTable)
+	}
+}
+
+// qualifyJoinTables qualifies the base and joined table-names, by the tenant table-prefix or schema (as the TableName);
+// the schema-qualified table-names of other schemas are refused
+func (crud *Crud) qualifyJoinTables(params *JoinSelectQueryParamsType) error {
+	if crud.tenantQualify == nil {
+		return nil
+	}
+	qualify := func(tableName string) (string, error) {
+		if schemaName, _, qualified := strings.Cut(tableName, "."); qualified && crud.tenant.Schema != "" {
+			if schemaName != crud.tenant.Schema {
+				return "", errors.New(fmt.Sprintf("cross-tenant schema(%v) is not permitted", schemaName))
+			}
+			return tableName, nil
+		}
+		return crud.tenantQualify(tableName), nil
+	}
+	tableName, err := qualify(params.TableName)
+	if err != nil {
+		return err
+	}
+	params.TableName = tableName
+	joins := make([]JoinParamType, len(params.Joins))
+	for i, join := range params.Joins {
+		if join.TableName, err = qualify(join.TableName); err != nil {
+			return err
+		}
+		joins[i] = join
+	}
+	params.Joins = joins
+	return nil
+}
+
+// tenantCheck verifies the crud-task against the tenant: the tenant resolution and the records appId
+// (cross-tenant records are refused), then applies the row-level app_id filter (shared-table strategy, AppIdFilter)
+func (crud *Crud) tenantCheck(taskType string, recs ActionParamsType, recordIds []string) (mcresponse.ResponseMessage, bool) {
+	if crud.tenantErr != nil {
+		return tenantErrMessage(crud.tenantErr.Error()), false
+	}
+	if crud.Tenancy == nil {
+		return crud.appIdCheck(taskType, recs, recordIds)
+	}
+	for _, rec := range recs {
+		for key, value := range rec {
+			if whereFieldName(key) == "app_id" && fmt.Sprintf("%v", value) != crud.tenant.TenantId {
+				return tenantErrMessage(fmt.Sprintf("cross-tenant record appId(%v) is not permitted", value)), false
+			}
+		}
+	}
+	return crud.appIdCheck(taskType, recs, recordIds)
+}
+
+func tenantErrMessage(errMsg string) mcresponse.ResponseMessage {
+	return mcresponse.GetResMessage("unAuthorized", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Tenant access error: %v", errMsg),
+		Value:   nil,
+	})
+}
@@ -0,0 +1,129 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: multi-tenancy routing test cases, by sqlite3 db
+
+package mcdbcrud
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+type tenantTestModel struct {
+	Id    string `json:"id" db:"id"`
+	AppId string `json:"appId" db:"app_id"`
+	Name  string `json:"name" db:"name"`
+}
+
+func TestTenancy(t *testing.T) {
+	tempDir := t.TempDir()
+	const tenantTable = "tenant_items"
+	openDb := func(filename string) *sqlx.DB {
+		db, dbErr := sqlx.Open("sqlite3", filepath.Join(tempDir, filename))
+		if dbErr != nil {
+			t.Fatalf("error opening sqlite3 db: %v", dbErr)
+		}
+		return db
+	}
+	acmeDb := openDb("acme.db")
+	globexDb := openDb("globex.db")
+	sharedDb := openDb("shared.db")
+	defer acmeDb.Close()
+	defer globexDb.Close()
+	defer sharedDb.Close()
+	_ = CreateTable(acmeDb, hookTestModel{}, tenantTable, "sqlite3")
+	_ = CreateTable(globexDb, hookTestModel{}, tenantTable, "sqlite3")
+	_ = CreateTable(sharedDb, hookTestModel{}, "acme_"+tenantTable, "sqlite3")
+	_ = CreateTable(sharedDb, tenantTestModel{}, tenantTable, "sqlite3")
+	countRecords := func(db *sqlx.DB, tableName string) int {
+		var count int
+		_ = db.QueryRowx("SELECT COUNT(*) FROM " + tableName).Scan(&count)
+		return count
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should route the crud-operations to the tenant database, and refuse unknown tenants:",
+		TestFunc: func() {
+			tenancy, _ := NewTenancy(TenancyOptionsType{Strategy: TenantDatabase})
+			_ = tenancy.Register(TenantStoreType{TenantId: "acme", AppDb: acmeDb})
+			_ = tenancy.Register(TenantStoreType{TenantId: "globex", AppDb: globexDb})
+			newCrud := func(appId string) *Crud {
+				return NewCrud(CrudParamsType{ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: tenantTable,
+					AppParams: AppParamsType{AppId: appId}}, CrudOptionsType{Tenancy: tenancy})
+			}
+			res := newCrud("acme").Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, countRecords(acmeDb, tenantTable), 1, "acme records should be: 1")
+			mctest.AssertEquals(t, countRecords(globexDb, tenantTable), 0, "globex records should be: 0")
+			res = newCrud("initech").GetAll()
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "unknown tenant response-code should be: unAuthorized")
+			res = newCrud("acme").Create(ActionParamsType{{"name": "def", "appId": "globex"}})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "cross-tenant record response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should route the crud-operations to the tenant table-prefix:",
+		TestFunc: func() {
+			tenancy, _ := NewTenancy(TenancyOptionsType{Strategy: TenantTablePrefix})
+			err := tenancy.Register(TenantStoreType{TenantId: "acme", TablePrefix: "acme_; DROP TABLE x;"})
+			mctest.AssertEquals(t, err != nil, true, "invalid table-prefix error should be: not nil")
+			_ = tenancy.Register(TenantStoreType{TenantId: "acme", TablePrefix: "acme_"})
+			crud := NewCrud(CrudParamsType{AppDb: sharedDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: tenantTable,
+				AppParams: AppParamsType{AppId: "acme"}}, CrudOptionsType{Tenancy: tenancy})
+			res := crud.Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, countRecords(sharedDb, "acme_"+tenantTable), 1, "acme_ table records should be: 1")
+			res = crud.GetJoin(JoinSelectQueryParamsType{TableName: tenantTable, TableAlias: "a",
+				Joins: []JoinParamType{{TableName: tenantTable, Alias: "b", OnCondition: "a.id = b.id"}}, SelectFields: []string{"a.id", "b.name"}})
+			value, _ := res.Value.(GetResultType)
+			mctest.AssertEquals(t, res.Code, "success", "join response-code should be: success")
+			mctest.AssertEquals(t, len(value.Records), 1, "joined acme_ table records should be: 1")
+			schemaCrud := NewCrud(CrudParamsType{AppDb: sharedDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{}, TableName: tenantTable,
+				Schema: "acme"}, CrudOptionsType{})
+			res = schemaCrud.GetJoin(JoinSelectQueryParamsType{TableName: tenantTable, TableAlias: "a",
+				Joins: []JoinParamType{{TableName: "globex." + tenantTable, Alias: "b", OnCondition: "a.id = b.id"}}})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "cross-tenant schema join response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should scope the shared-table records by app_id, and refuse cross-tenant ids:",
+		TestFunc: func() {
+			tenancy, _ := NewTenancy(TenancyOptionsType{
+				Strategy: TenantSharedTable,
+				TenantIdFunc: func(appParams AppParamsType, userInfo UserInfoType) string {
+					return map[string]string{"u-acme": "acme", "u-globex": "globex"}[userInfo.UserId]
+				},
+			})
+			newCrud := func(userId string) *Crud {
+				return NewCrud(CrudParamsType{AppDb: sharedDb, ModelRef: tenantTestModel{}, ModelPointer: &tenantTestModel{},
+					TableName: tenantTable, UserInfo: UserInfoType{UserId: userId}}, CrudOptionsType{Tenancy: tenancy})
+			}
+			createRes := newCrud("u-acme").Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, createRes.Code, "success", "create response-code should be: success")
+			_ = newCrud("u-globex").Create(ActionParamsType{{"name": "def"}})
+			acmeIds := createRes.Value.(CrudResultType).RecordIds
+			res := newCrud("u-acme").GetAll()
+			value, _ := res.Value.(GetResultType)
+			mctest.AssertEquals(t, len(value.Records), 1, "acme records should be: 1")
+			mctest.AssertEquals(t, value.Records[0]["appId"], "acme", "record appId should be: acme")
+			crud := newCrud("u-globex")
+			crud.QueryParams = QueryParamType{"appId": "acme"}
+			res = crud.GetByParam()
+			value, _ = res.Value.(GetResultType)
+			mctest.AssertEquals(t, len(value.Records), 1, "globex query-params records should be: 1 (globex)")
+			res = newCrud("u-globex").GetById(acmeIds[0])
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "cross-tenant get response-code should be: unAuthorized")
+			res = newCrud("u-globex").DeleteById(acmeIds[0])
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "cross-tenant delete response-code should be: unAuthorized")
+			_ = newCrud("u-globex").DeleteAll()
+			mctest.AssertEquals(t, countRecords(sharedDb, tenantTable), 1, "shared-table records should be: 1 (acme)")
+			res = newCrud("u-acme").CustomSelectQuery(CustomSelectQueryParamsType{})
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "custom select-query response-code should be: unAuthorized")
+		},
+	})
+
+	mctest.PostTestResult()
+}
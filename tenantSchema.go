@@ -0,0 +1,88 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: schema-per-tenant (postgres): the transaction search_path, and the tenant schema migrations
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/abbeymart/mcdbcrud/migrations"
+	"github.com/jmoiron/sqlx"
+)
+
+// beginTx starts the write transaction, with the search_path set to the tenant (or per-call) schema (postgres),
+// for the unqualified identifiers of the hooks and the triggers
+func (crud *Crud) beginTx() (*sqlx.Tx, error) {
+	tx, err := crud.AppDb.Beginx()
+	if err != nil {
+		return nil, err
+	}
+	if crud.tenant.Schema != "" && crud.AppDb.DriverName() == "postgres" {
+		if _, err = tx.Exec(searchPathQuery(crud.tenant.Schema)); err != nil {
+			_ = tx.Rollback()
+			return nil, errors.New(fmt.Sprintf("error setting the search_path[%v]: %v", crud.tenant.Schema, err.Error()))
+		}
+	}
+	return tx, nil
+}
+
+// searchPathQuery returns the transaction-scoped search_path query of the (validated) schema
+func searchPathQuery(schema string) string {
+	return fmt.Sprintf("SET LOCAL search_path TO %v, public", schema)
+}
+
+// MigrateTenantSchema creates the tenant schema, if not exists, and applies the migrations into it (postgres):
+// each migration runs with the search_path set to the schema, and the applied versions are tracked
+// in the schema migrations table (options.TableName, default: schema_migrations)
+func MigrateTenantSchema(appDb *sqlx.DB, dbType string, schema string, migrationList []migrations.Migration, options migrations.MigratorOptionsType) ([]int64, error) {
+	if dbType != "postgres" {
+		return nil, errors.New(fmt.Sprintf("schema-per-tenant migrations are not supported for the db-type(%v)", dbType))
+	}
+	if appDb == nil {
+		return nil, errors.New("db-connection is required")
+	}
+	if !identifierRegex.MatchString(schema) {
+		return nil, errors.New(fmt.Sprintf("invalid schema(%v)", schema))
+	}
+	if _, err := appDb.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %v", schema)); err != nil {
+		return nil, errors.New(fmt.Sprintf("error creating schema[%v]: %v", schema, err.Error()))
+	}
+	if options.TableName == "" {
+		options.TableName = migrations.DefaultTableName
+	}
+	options.TableName = schema + "." + options.TableName
+	migrator := migrations.NewMigrator(appDb, dbType, options)
+	for _, migration := range migrationList {
+		if err := migrator.Add(schemaMigration(schema, migration)); err != nil {
+			return nil, err
+		}
+	}
+	return migrator.Up()
+}
+
+// schemaMigration returns the migration, with the up and down tasks run in the schema search_path
+func schemaMigration(schema string, migration migrations.Migration) migrations.Migration {
+	inSchema := func(task migrations.MigrationFunc, script string) migrations.MigrationFunc {
+		if task == nil && script == "" {
+			return nil
+		}
+		return func(tx *sqlx.Tx) error {
+			if _, err := tx.Exec(searchPathQuery(schema)); err != nil {
+				return err
+			}
+			if task != nil {
+				return task(tx)
+			}
+			_, err := tx.Exec(script)
+			return err
+		}
+	}
+	return migrations.Migration{
+		Version: migration.Version,
+		Name:    migration.Name,
+		Up:      inSchema(migration.Up, migration.UpSQL),
+		Down:    inSchema(migration.Down, migration.DownSQL),
+	}
+}
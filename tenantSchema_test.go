@@ -0,0 +1,96 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: schema-per-tenant test cases, by sqlite3 db (attached database, as the schema)
+
+package mcdbcrud
+
+import (
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/abbeymart/mcdbcrud/migrations"
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+	"github.com/mattn/go-sqlite3"
+)
+
+func TestTenantSchema(t *testing.T) {
+	tempDir := t.TempDir()
+	// the attached database (per connection), as the acme schema
+	driverName := "sqlite3_" + filepath.Base(tempDir)
+	sql.Register(driverName, &sqlite3.SQLiteDriver{
+		ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+			_, err := conn.Exec(fmt.Sprintf("ATTACH DATABASE '%v' AS acme", filepath.Join(tempDir, "acme.db")), nil)
+			return err
+		},
+	})
+	sqliteDb, dbErr := sqlx.Open(driverName, filepath.Join(tempDir, "main.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	const schemaTable = "schema_items"
+	_ = CreateTable(sqliteDb, hookTestModel{}, schemaTable, "sqlite3")
+	_ = CreateTable(sqliteDb, hookTestModel{}, "acme."+schemaTable, "sqlite3")
+	countRecords := func(tableName string) int {
+		var count int
+		_ = sqliteDb.QueryRowx("SELECT COUNT(*) FROM " + tableName).Scan(&count)
+		return count
+	}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should qualify the table-name by the per-call schema, and refuse invalid schemas:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{},
+				TableName: schemaTable, Schema: "acme"}, CrudOptionsType{})
+			mctest.AssertEquals(t, crud.TableName, "acme."+schemaTable, "table-name should be: acme."+schemaTable)
+			res := crud.Create(ActionParamsType{{"name": "abc"}})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+			mctest.AssertEquals(t, countRecords("acme."+schemaTable), 1, "acme schema records should be: 1")
+			mctest.AssertEquals(t, countRecords("main."+schemaTable), 0, "main schema records should be: 0")
+			crud = NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{},
+				TableName: schemaTable, Schema: "acme; DROP TABLE x"}, CrudOptionsType{})
+			mctest.AssertEquals(t, crud.GetAll().Code, "unAuthorized", "invalid schema response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should route the tenant to its schema, with the tenant-local or global audit and access tables:",
+		TestFunc: func() {
+			tenancy, _ := NewTenancy(TenancyOptionsType{Strategy: TenantSchema})
+			err := tenancy.Register(TenantStoreType{TenantId: "acme", Schema: "acme.x"})
+			mctest.AssertEquals(t, err != nil, true, "invalid schema error should be: not nil")
+			_ = tenancy.Register(TenantStoreType{TenantId: "acme", Schema: "acme"})
+			newCrud := func(schema string, options CrudOptionsType) *Crud {
+				options.Tenancy = tenancy
+				return NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: hookTestModel{}, ModelPointer: &hookTestModel{},
+					TableName: schemaTable, Schema: schema, AppParams: AppParamsType{AppId: "acme"}}, options)
+			}
+			crud := newCrud("", CrudOptionsType{TenantLocalAudit: true})
+			mctest.AssertEquals(t, crud.TableName, "acme."+schemaTable, "tenant table-name should be: acme."+schemaTable)
+			mctest.AssertEquals(t, crud.AuditTable, "acme.audits", "tenant-local audit-table should be: acme.audits")
+			mctest.AssertEquals(t, crud.AccessTable, "accesses", "global access-table should be: accesses")
+			crud = newCrud("", CrudOptionsType{TenantLocalAccess: true})
+			mctest.AssertEquals(t, crud.AuditTable, "audits", "global audit-table should be: audits")
+			mctest.AssertEquals(t, crud.UserTable, "acme.users", "tenant-local user-table should be: acme.users")
+			res := newCrud("", CrudOptionsType{}).Create(ActionParamsType{{"name": "def"}})
+			mctest.AssertEquals(t, res.Code, "success", "tenant create response-code should be: success")
+			mctest.AssertEquals(t, countRecords("acme."+schemaTable), 2, "acme schema records should be: 2")
+			res = newCrud("main", CrudOptionsType{}).GetAll()
+			mctest.AssertEquals(t, res.Code, "unAuthorized", "cross-tenant schema response-code should be: unAuthorized")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should refuse the tenant schema migrations, for the non-postgres db-type or invalid schema:",
+		TestFunc: func() {
+			migration := migrations.Migration{Version: 1, Name: "items", UpSQL: "CREATE TABLE items (id TEXT)"}
+			_, err := MigrateTenantSchema(sqliteDb, "sqlite3", "acme", []migrations.Migration{migration}, migrations.MigratorOptionsType{})
+			mctest.AssertEquals(t, err != nil, true, "sqlite3 migrations error should be: not nil")
+			_, err = MigrateTenantSchema(sqliteDb, "postgres", "acme; DROP TABLE x", []migrations.Migration{migration}, migrations.MigratorOptionsType{})
+			mctest.AssertEquals(t, err != nil, true, "invalid schema error should be: not nil")
+		},
+	})
+
+	mctest.PostTestResult()
+}
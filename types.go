@@ -15,8 +15,9 @@ type DbConnectionType *sqlx.DB
 
 type DbSecureType struct {
 	SecureAccess bool   `json:"secureAccess"`
-	SecureCert   string `json:"secureCert"`
+	SecureCert   string `json:"secureCert"` // client cert (with SecureKey), or root cert (without SecureKey, legacy)
 	SecureKey    string `json:"secureKey"`
+	RootCert     string `json:"rootCert"`
 	SslMode      string `json:"sslMode"`
 }
 
@@ -37,20 +38,25 @@ type DbConfigType struct {
 type DbConnectOptions map[string]interface{}
 
 type DbConfig struct {
-	DbType        string           `json:"dbType"`
-	Host          string           `json:"host"`
-	Username      string           `json:"username"`
-	Password      string           `json:"password"`
-	DbName        string           `json:"dbName"`
-	Filename      string           `json:"filename"`
-	Location      string           `json:"location"`
-	Port          uint32           `json:"port"`
-	PoolSize      uint             `json:"poolSize"`
-	Url           string           `json:"url"`
-	Timezone      string           `json:"timezone"`
-	SecureOptions DbSecureType     `json:"secureOptions"`
-	Options       DbConnectOptions `json:"options"`
-	PermitDBUrl   bool             `json:"permitDBUrl"`
+	DbType          string           `json:"dbType"`
+	Host            string           `json:"host"`
+	Username        string           `json:"username"`
+	Password        string           `json:"password"`
+	PasswordFile    string           `json:"passwordFile"` // password file-path (e.g. secret mount), see LoadDbConfig
+	DbName          string           `json:"dbName"`
+	Filename        string           `json:"filename"`
+	Location        string           `json:"location"`
+	Port            uint32           `json:"port"`
+	PoolSize        uint             `json:"poolSize"` // max open connections
+	MaxIdleConns    int              `json:"maxIdleConns"`
+	ConnMaxLifetime int              `json:"connMaxLifetime"` // secs
+	ConnMaxIdleTime int              `json:"connMaxIdleTime"` // secs
+	Name            string           `json:"name"`            // registry connection-name, default: ConnectionName()
+	Url             string           `json:"url"`
+	Timezone        string           `json:"timezone"`
+	SecureOptions   DbSecureType     `json:"secureOptions"`
+	Options         DbConnectOptions `json:"options"`
+	PermitDBUrl     bool             `json:"permitDBUrl"`
 }
 
 type CrudTasksType struct {
@@ -110,34 +116,34 @@ type UserInfoType struct {
 
 type AppBaseModelType struct {
 	Id          string    `json:"id" db:"id"`
-	Language    string    `json:"language" db:"language"`
-	Description string    `json:"description" db:"description"`
-	AppId       string    `json:"appId" db:"app_id"`       // application-id in a multi-hosted apps environment (e.g. cloud-env)
-	IsActive    bool      `json:"isActive" db:"is_active"` // => activate by modelOptionsType settings...
-	CreatedBy   string    `json:"createdBy" db:"created_by"`
-	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
-	UpdatedBy   string    `json:"updatedBy" db:"updated_by"`
-	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
+	Language    string    `json:"language" db:"language" ddl:"default:'en-US'"`
+	Description string    `json:"description" db:"description" ddl:"null"`
+	AppId       string    `json:"appId" db:"app_id" ddl:"null;index"`         // application-id in a multi-hosted apps environment (e.g. cloud-env)
+	IsActive    bool      `json:"isActive" db:"is_active" ddl:"default:true"` // => activate by modelOptionsType settings...
+	CreatedBy   string    `json:"createdBy" db:"created_by" ddl:"null"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at" ddl:"default:CURRENT_TIMESTAMP"`
+	UpdatedBy   string    `json:"updatedBy" db:"updated_by" ddl:"null"`
+	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at" ddl:"default:CURRENT_TIMESTAMP"`
 }
 
 type BaseModelType struct {
 	Id          string    `json:"id" db:"id"`
-	Language    string    `json:"language" db:"language"`
-	Description string    `json:"description" db:"description"`
-	IsActive    bool      `json:"isActive" db:"is_active"` // => activate by modelOptionsType settings...
-	CreatedBy   string    `json:"createdBy" db:"created_by"`
-	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
-	UpdatedBy   string    `json:"updatedBy" db:"updated_by"`
-	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
+	Language    string    `json:"language" db:"language" ddl:"default:'en-US'"`
+	Description string    `json:"description" db:"description" ddl:"null"`
+	IsActive    bool      `json:"isActive" db:"is_active" ddl:"default:true"` // => activate by modelOptionsType settings...
+	CreatedBy   string    `json:"createdBy" db:"created_by" ddl:"null"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at" ddl:"default:CURRENT_TIMESTAMP"`
+	UpdatedBy   string    `json:"updatedBy" db:"updated_by" ddl:"null"`
+	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at" ddl:"default:CURRENT_TIMESTAMP"`
 }
 
 type RelationBaseModelType struct {
-	Description string    `json:"description" db:"description"`
-	IsActive    bool      `json:"isActive" db:"is_active"` // => activate by modelOptionsType settings...
-	CreatedBy   string    `json:"createdBy" db:"created_by"`
-	CreatedAt   time.Time `json:"createdAt" db:"created_at"`
-	UpdatedBy   string    `json:"updatedBy" db:"updated_by"`
-	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at"`
+	Description string    `json:"description" db:"description" ddl:"null"`
+	IsActive    bool      `json:"isActive" db:"is_active" ddl:"default:true"` // => activate by modelOptionsType settings...
+	CreatedBy   string    `json:"createdBy" db:"created_by" ddl:"null"`
+	CreatedAt   time.Time `json:"createdAt" db:"created_at" ddl:"default:CURRENT_TIMESTAMP"`
+	UpdatedBy   string    `json:"updatedBy" db:"updated_by" ddl:"null"`
+	UpdatedAt   time.Time `json:"updatedAt" db:"updated_at" ddl:"default:CURRENT_TIMESTAMP"`
 }
 
 type AppBaseModelPtrType struct {
@@ -303,59 +309,79 @@ const (
 
 // CrudParamsType is the struct type for receiving, composing and passing CRUD inputs
 type CrudParamsType struct {
-	ModelRef      interface{}      `json:"-"`
-	ModelPointer  interface{}      `json:"-"`
-	AppDb         *sqlx.DB         `json:"-"`
-	TableName     string           `json:"-"`
-	UserInfo      UserInfoType     `json:"userInfo"`
-	ActionParams  ActionParamsType `json:"actionParams"`
-	QueryParams   QueryParamType   `json:"queryParams"`
-	RecordIds     []string         `json:"recordIds"`
-	ProjectParams ProjectParamType `json:"projectParams"`
-	SortParams    SortParamType    `json:"sortParams"`
-	Token         string           `json:"token"`
-	Skip          int              `json:"skip"`
-	Limit         int              `json:"limit"`
-	TaskName      string           `json:"taskName"`
-	TaskType      string           `json:"taskType"`
-	AppParams     AppParamsType    `json:"appParams"`
+	ModelRef        interface{}      `json:"-"`
+	ModelPointer    interface{}      `json:"-"`
+	AppDb           *sqlx.DB         `json:"-"`
+	TableName       string           `json:"-"`
+	UserInfo        UserInfoType     `json:"userInfo"`
+	ActionParams    ActionParamsType `json:"actionParams"`
+	QueryParams     QueryParamType   `json:"queryParams"`
+	RecordIds       []string         `json:"recordIds"`
+	ProjectParams   ProjectParamType `json:"projectParams"`
+	SortParams      SortParamType    `json:"sortParams"`
+	Token           string           `json:"token"`
+	Skip            int              `json:"skip"`
+	Limit           int              `json:"limit"`
+	TaskName        string           `json:"taskName"`
+	TaskType        string           `json:"taskType"`
+	AppParams       AppParamsType    `json:"appParams"`
+	ReadYourWrites  bool             `json:"readYourWrites"` // reads from the primary (AppDb), not the ReadReplicas
+	PermittedAppIds []string         `json:"-"`              // row-level app_id filter (AppIdFilter), e.g. the caller's subscribed appIds
+	Schema          string           `json:"-"`              // schema-qualifier (postgres schema-per-tenant), e.g. "acme" => acme.<TableName>
 }
 
 type CrudOptionsType struct {
-	CheckAccess           bool
-	CacheResult           bool
-	BulkCreate            bool
-	AccessDb              *sqlx.DB
-	AuditDb               *sqlx.DB
-	ServiceDb             *sqlx.DB
-	AuditTable            string
-	ServiceTable          string
-	UserTable             string
-	RoleTable             string
-	AccessTable           string
-	VerifyTable           string
-	ProfileTable          string
-	UserRoleTable         string
-	MaxQueryLimit         int
-	LogCrud               bool
-	LogCreate             bool
-	LogUpdate             bool
-	LogRead               bool
-	LogDelete             bool
-	LogLogin              bool
-	LogLogout             bool
-	UnAuthorizedMessage   string
-	RecExistMessage       string
-	CacheExpire           int
-	LoginTimeout          int
-	UsernameExistsMessage string
-	EmailExistsMessage    string
-	MsgFrom               string
-	ModelOptions          ModelOptionsType
-	FieldSeparator        string
-	AppDbs                []string
-	AppTables             []string
-	QueryFieldType        string
+	CheckAccess               bool
+	CacheResult               bool
+	BulkCreate                bool
+	AccessDb                  *sqlx.DB
+	AuditDb                   *sqlx.DB
+	ServiceDb                 *sqlx.DB
+	AuditTable                string
+	ServiceTable              string
+	UserTable                 string
+	RoleTable                 string
+	AccessTable               string
+	VerifyTable               string
+	ProfileTable              string
+	UserRoleTable             string
+	RoleParentTable           string // role hierarchy (RoleParentModel), default: role_parents
+	MaxQueryLimit             int
+	LogCrud                   bool
+	LogCreate                 bool
+	LogUpdate                 bool
+	LogRead                   bool
+	LogDelete                 bool
+	LogLogin                  bool
+	LogLogout                 bool
+	UnAuthorizedMessage       string
+	RecExistMessage           string
+	CacheExpire               int
+	CacheStaleExpire          int    // secs, serves the expired cache-result while refreshing (stale-while-revalidate)
+	LoginTimeout              int    // secs, the login session expiry, default: DefaultLoginTimeout (1 hour)
+	TokenSecret               string // HMAC-SHA256 key of the stored access tokens; plaintext (legacy) tokens remain valid until expiry
+	UsernameExistsMessage     string
+	EmailExistsMessage        string
+	MsgFrom                   string
+	ModelOptions              ModelOptionsType
+	FieldSeparator            string
+	AppDbs                    []string
+	AppTables                 []string
+	QueryFieldType            string
+	StrictSchema              bool // validates ModelRef against TableName (AppDb) once; the drift error is returned by the crud-operations (and Err)
+	Hooks                     CrudHooksType
+	Outbox                    bool                                   // writes the create/update/delete change-events into OutboxTable, within the transaction
+	OutboxTable               string                                 // default: outbox_events
+	InvalidationBus           InvalidationBus                        // broadcasts the write cache-invalidations to the other app instances
+	OnError                   func(err error)                        // handles the non-fatal errors, e.g. the invalidation publish error; ignored, if nil
+	Cache                     Cache                                  // query-results cache backend, default: DefaultCache (mccache)
+	CacheKeyFunc              func(params CacheKeyParamsType) string // overrides ComputeCacheKey
+	DisablePreparedStatements bool                                   // sends the generated sql unprepared, e.g. for poolers without prepared-statements support
+	ReadReplicas              *ReplicaSet                            // read-replicas for the Get*, CustomSelectQuery and count reads; writes use AppDb (primary)
+	Tenancy                   *Tenancy                               // multi-tenancy routing, by AppParams.AppId or UserInfo (see NewTenancy)
+	AppIdFilter               bool                                   // row-level app_id filter, by PermittedAppIds, for all the reads, updates and deletes
+	TenantLocalAudit          bool                                   // audit-logs in the tenant database, schema or table-prefix, instead of the global AuditDb/AuditTable
+	TenantLocalAccess         bool                                   // access tables in the tenant database, schema or table-prefix, instead of the global AccessDb tables
 }
 
 type SelectQueryOptions struct {
@@ -363,6 +389,33 @@ type SelectQueryOptions struct {
 	Limit int
 }
 
+const (
+	InnerJoin = "INNER JOIN"
+	LeftJoin  = "LEFT JOIN"
+)
+
+// JoinParamType specifies a table to join to the base-table of a select-query
+type JoinParamType struct {
+	JoinType    string `json:"joinType"` // InnerJoin or LeftJoin, defaults to InnerJoin
+	TableName   string `json:"tableName"`
+	Alias       string `json:"alias"`
+	OnCondition string `json:"onCondition"` // e.g. "u.id = a.log_by"
+}
+
+// JoinSelectQueryParamsType is the struct type for composing select-queries with joined tables.
+// SelectFields and QueryParams/SortParams keys may be qualified by the table-alias, e.g. "u.username"
+type JoinSelectQueryParamsType struct {
+	TableName    string          `json:"tableName"`
+	TableAlias   string          `json:"tableAlias"`
+	Joins        []JoinParamType `json:"joins"`
+	SelectFields []string        `json:"selectFields"`
+	QueryParams  QueryParamType  `json:"queryParams"`
+	SortParams   SortParamType   `json:"sortParams"`
+	ModelPointer interface{}     `json:"-"` // optional, scan rows into the struct, otherwise into nested maps
+	Skip         int             `json:"skip"`
+	Limit        int             `json:"limit"`
+}
+
 type MessageObject map[string]string
 
 type ValidateResponseType struct {
@@ -560,7 +613,7 @@ type GetCrudParamsType struct {
 
 type CustomSelectQueryParamsType struct {
 	SelectQuery                string          `json:"selectQuery"`
-	CountQuery                 string          `json:"countQuery"`
+	CountQuery                 string          `json:"countQuery"` // optional, uses the same positional-field-values as the selectQuery
 	TableName                  string          `json:"tableName"`
 	ModelPointer               interface{}     `json:"modelPointer"`
 	QueryPositionalFieldValues []interface{}   `json:"queryPositionalFieldValues"`
@@ -0,0 +1,347 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: declarative (struct-tag) validation of the create/update records
+
+package mcdbcrud
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/abbeymart/mcresponse"
+	"github.com/asaskevich/govalidator"
+)
+
+// ValidateTag is the struct-tag for the field validation rules, separated by semicolon, e.g.
+// `validate:"required;len:2,50"`, `validate:"min:0;max:100"`, `validate:"oneof:create|update|delete"`,
+// `validate:"required;regex:^[a-z0-9_]+$"`
+//   - required: on create, the field must be present and non-empty; on update, if present, non-empty
+//   - min:n | max:n: numeric value, or length of string, slice and map values
+//   - len:n | len:min,max: exact or range of string length (characters)
+//   - regex:pattern: string pattern, must be the last rule (the pattern may include semicolons)
+//   - email | uuid: valid email-address | uuid string
+//   - oneof:a|b|c: one of the values
+//   - name: custom validator, registered by RegisterValidator
+const ValidateTag = "validate"
+
+// ValidatorFunc is the custom (named) validator function, returns the validation error for the field value
+type ValidatorFunc func(value interface{}) error
+
+type validateRule struct {
+	name  string
+	value string
+	regex *regexp.Regexp
+}
+
+type fieldRules struct {
+	fieldName string
+	rules     []validateRule
+}
+
+var (
+	validators      = map[string]ValidatorFunc{}
+	validatorsMutex sync.RWMutex
+	modelRules      sync.Map // model validation-rules, by model-type
+)
+
+// RegisterValidator registers the custom (named) validator, for use in the validate struct-tag
+func RegisterValidator(name string, validator ValidatorFunc) {
+	validatorsMutex.Lock()
+	defer validatorsMutex.Unlock()
+	validators[name] = validator
+}
+
+func getValidator(name string) (ValidatorFunc, bool) {
+	validatorsMutex.RLock()
+	defer validatorsMutex.RUnlock()
+	validator, ok := validators[name]
+	return validator, ok
+}
+
+// parseValidateTag computes the validation rules from the validate-tag value
+func parseValidateTag(tag string) ([]validateRule, error) {
+	var rules []validateRule
+	for tag != "" {
+		var item string
+		if strings.HasPrefix(strings.TrimSpace(tag), "regex:") {
+			item, tag = strings.TrimSpace(tag), ""
+		} else {
+			item, tag, _ = strings.Cut(tag, ";")
+			item = strings.TrimSpace(item)
+		}
+		if item == "" {
+			continue
+		}
+		ruleName, ruleValue, _ := strings.Cut(item, ":")
+		rule := validateRule{name: strings.TrimSpace(ruleName), value: ruleValue}
+		switch rule.name {
+		case "required", "email", "uuid":
+		case "min", "max":
+			if _, err := strconv.ParseFloat(strings.TrimSpace(rule.value), 64); err != nil {
+				return nil, errors.New(fmt.Sprintf("invalid %v value(%v)", rule.name, rule.value))
+			}
+		case "len":
+			for _, size := range strings.Split(rule.value, ",") {
+				if _, err := strconv.Atoi(strings.TrimSpace(size)); err != nil {
+					return nil, errors.New(fmt.Sprintf("invalid len value(%v)", rule.value))
+				}
+			}
+		case "regex":
+			regex, err := regexp.Compile(rule.value)
+			if err != nil {
+				return nil, errors.New(fmt.Sprintf("invalid regex(%v): %v", rule.value, err.Error()))
+			}
+			rule.regex = regex
+		case "oneof":
+			if rule.value == "" {
+				return nil, errors.New("oneof values are required")
+			}
+		default:
+			// custom validators may be registered after the model rules are computed, checked on validation
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// getModelRules returns the validation-rules of the model-struct, by column/underscore field-name (cached by model-type)
+func getModelRules(modelRef interface{}) (map[string]fieldRules, error) {
+	modelType := reflect.TypeOf(modelRef)
+	if modelType.Kind() == reflect.Ptr {
+		modelType = modelType.Elem()
+	}
+	if modelType.Kind() != reflect.Struct {
+		return nil, errors.New(fmt.Sprintf("model parameter must be of type struct{}"))
+	}
+	if rules, ok := modelRules.Load(modelType); ok {
+		return rules.(map[string]fieldRules), nil
+	}
+	rules := map[string]fieldRules{}
+	if err := computeModelRules(modelType, rules); err != nil {
+		return nil, err
+	}
+	modelRules.Store(modelType, rules)
+	return rules, nil
+}
+
+func computeModelRules(modelType reflect.Type, rules map[string]fieldRules) error {
+	for i := 0; i < modelType.NumField(); i++ {
+		field := modelType.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			if err := computeModelRules(field.Type, rules); err != nil {
+				return err
+			}
+			continue
+		}
+		tag := field.Tag.Get(ValidateTag)
+		if !field.IsExported() || tag == "" {
+			continue
+		}
+		fieldRuleItems, err := parseValidateTag(tag)
+		if err != nil {
+			return errors.New(fmt.Sprintf("field[%v]: %v", field.Name, err.Error()))
+		}
+		fieldName := field.Tag.Get("json")
+		if fieldName == "" || fieldName == "-" {
+			fieldName = field.Name
+		}
+		fieldName, _, _ = strings.Cut(fieldName, ",")
+		columnName := field.Tag.Get("db")
+		if columnName == "" || columnName == "-" {
+			columnName = govalidator.CamelCaseToUnderscore(field.Name)
+		}
+		rules[columnName] = fieldRules{fieldName: fieldName, rules: fieldRuleItems}
+	}
+	return nil
+}
+
+// isEmptyValue determines if the value is nil, empty string, or empty slice/map
+func isEmptyValue(value interface{}) bool {
+	if value == nil {
+		return true
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if rv.IsNil() {
+			return true
+		}
+		return isEmptyValue(rv.Elem().Interface())
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return rv.Len() == 0
+	default:
+		return false
+	}
+}
+
+// sizeValue returns the numeric value, or the length of string, slice and map values
+func sizeValue(value interface{}) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return 0, false
+		}
+		rv = rv.Elem()
+	}
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	case reflect.String:
+		return float64(utf8.RuneCountInString(rv.String())), true
+	case reflect.Slice, reflect.Map, reflect.Array:
+		return float64(rv.Len()), true
+	default:
+		return 0, false
+	}
+}
+
+// validateValue validates the field value by the rule, and returns the validation message ("" if valid)
+func validateValue(rule validateRule, value interface{}) string {
+	strValue, isString := value.(string)
+	if ptrValue, ok := value.(*string); ok && ptrValue != nil {
+		strValue, isString = *ptrValue, true
+	}
+	switch rule.name {
+	case "min", "max":
+		limit, _ := strconv.ParseFloat(strings.TrimSpace(rule.value), 64)
+		size, ok := sizeValue(value)
+		if !ok {
+			return fmt.Sprintf("%v rule is not applicable to the value type", rule.name)
+		}
+		if rule.name == "min" && size < limit {
+			return fmt.Sprintf("must be at least %v", rule.value)
+		}
+		if rule.name == "max" && size > limit {
+			return fmt.Sprintf("must be at most %v", rule.value)
+		}
+	case "len":
+		if !isString {
+			return "must be a string"
+		}
+		length := utf8.RuneCountInString(strValue)
+		sizes := strings.Split(rule.value, ",")
+		minLen, _ := strconv.Atoi(strings.TrimSpace(sizes[0]))
+		maxLen := minLen
+		if len(sizes) > 1 {
+			maxLen, _ = strconv.Atoi(strings.TrimSpace(sizes[1]))
+		}
+		if length < minLen || length > maxLen {
+			if minLen == maxLen {
+				return fmt.Sprintf("length must be %v", minLen)
+			}
+			return fmt.Sprintf("length must be between %v and %v", minLen, maxLen)
+		}
+	case "regex":
+		if !isString || !rule.regex.MatchString(strValue) {
+			return fmt.Sprintf("must match the pattern: %v", rule.value)
+		}
+	case "email":
+		if !isString || !govalidator.IsEmail(strValue) {
+			return "must be a valid email-address"
+		}
+	case "uuid":
+		if !isString || !govalidator.IsUUID(strValue) {
+			return "must be a valid uuid"
+		}
+	case "oneof":
+		options := strings.Split(rule.value, "|")
+		if !ArrayStringContains(options, fmt.Sprintf("%v", value)) {
+			return fmt.Sprintf("must be one of: %v", strings.Join(options, ", "))
+		}
+	default:
+		validator, ok := getValidator(rule.name)
+		if !ok {
+			return fmt.Sprintf("unknown validator(%v)", rule.name)
+		}
+		if err := validator(value); err != nil {
+			return err.Error()
+		}
+	}
+	return ""
+}
+
+// ValidateRecords validates the create/update records by the model-struct validate-tags, and returns the
+// per-field validation messages, by record-index and field-name, e.g. {"record[0].email": "must be a valid email-address"}
+func ValidateRecords(modelRef interface{}, recs ActionParamsType, taskType string) ValidateResponseType {
+	errs := MessageObject{}
+	if modelRef == nil {
+		return ValidateResponseType{Ok: true, Errors: errs}
+	}
+	rules, err := getModelRules(modelRef)
+	if err != nil {
+		errs["model"] = fmt.Sprintf("validation rules error: %v", err.Error())
+		return ValidateResponseType{Ok: false, Errors: errs}
+	}
+	// deterministic rule order
+	columnNames := make([]string, 0, len(rules))
+	for columnName := range rules {
+		columnNames = append(columnNames, columnName)
+	}
+	sort.Strings(columnNames)
+	for recIndex, rec := range recs {
+		// record-fields by column/underscore name
+		recFields := map[string]interface{}{}
+		for key, val := range rec {
+			recFields[govalidator.CamelCaseToUnderscore(key)] = val
+		}
+		for _, columnName := range columnNames {
+			fieldRule := rules[columnName]
+			errKey := fmt.Sprintf("record[%v].%v", recIndex, fieldRule.fieldName)
+			value, present := recFields[columnName]
+			required := false
+			for _, rule := range fieldRule.rules {
+				if rule.name == "required" {
+					required = true
+				}
+			}
+			if isEmptyValue(value) {
+				if required && (present || taskType == CreateTask) {
+					errs[errKey] = "is required"
+				}
+				continue
+			}
+			for _, rule := range fieldRule.rules {
+				if rule.name == "required" {
+					continue
+				}
+				if msg := validateValue(rule, value); msg != "" {
+					errs[errKey] = msg
+					break
+				}
+			}
+		}
+	}
+	return ValidateResponseType{Ok: len(errs) < 1, Errors: errs}
+}
+
+// validateRecords validates the crud records, by the crud ModelRef, and returns the paramsError response (if invalid)
+func (crud *Crud) validateRecords(recs ActionParamsType, taskType string) (mcresponse.ResponseMessage, bool) {
+	validateRes := ValidateRecords(crud.ModelRef, recs, taskType)
+	if validateRes.Ok {
+		return mcresponse.ResponseMessage{}, true
+	}
+	errKeys := make([]string, 0, len(validateRes.Errors))
+	for key := range validateRes.Errors {
+		errKeys = append(errKeys, key)
+	}
+	sort.Strings(errKeys)
+	var messages []string
+	for _, key := range errKeys {
+		messages = append(messages, fmt.Sprintf("%v: %v", key, validateRes.Errors[key]))
+	}
+	return mcresponse.GetResMessage("paramsError", mcresponse.ResponseMessageOptions{
+		Message: fmt.Sprintf("Validation error(s): %v", strings.Join(messages, " | ")),
+		Value:   validateRes.Errors,
+	}), false
+}
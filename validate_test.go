@@ -0,0 +1,101 @@
+// @Author: abbeymart | Abi Akindele | @Created: 2026-10-19 | @Updated: 2026-10-19
+// @Company: mConnect.biz | @License: MIT
+// @Description: struct-tag validation test cases
+
+package mcdbcrud
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/abbeymart/mctest"
+	"github.com/jmoiron/sqlx"
+)
+
+type validateTestModel struct {
+	Id       string  `json:"id" db:"id" validate:"uuid"`
+	Name     string  `json:"name" db:"name" validate:"required;len:2,20"`
+	Email    string  `json:"email" db:"email" validate:"required;email"`
+	Age      int     `json:"age" db:"age" validate:"min:18;max:120"`
+	Role     string  `json:"role" db:"role" validate:"oneof:admin|user"`
+	Code     string  `json:"code" db:"code" validate:"regex:^[A-Z]{3};[0-9]+$"`
+	Slug     *string `json:"slug" db:"slug" validate:"slug"`
+	Optional string  `json:"optional" db:"optional"`
+}
+
+func TestValidateRecords(t *testing.T) {
+	RegisterValidator("slug", func(value interface{}) error {
+		if str, ok := value.(string); !ok || strings.ToLower(str) != str {
+			return errors.New("must be a lowercase slug")
+		}
+		return nil
+	})
+	sqliteDb, dbErr := sqlx.Open("sqlite3", filepath.Join(t.TempDir(), "validate.db"))
+	if dbErr != nil {
+		t.Fatalf("error opening sqlite3 db: %v", dbErr)
+	}
+	defer sqliteDb.Close()
+	_ = CreateTable(sqliteDb, validateTestModel{}, "validate_items", "sqlite3")
+	validRec := ActionParamType{"name": "Abi", "email": "abc@xyz.com", "age": 30, "role": "admin", "code": "ABC;123", "slug": "abc-xyz"}
+
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate the valid create-record:",
+		TestFunc: func() {
+			res := ValidateRecords(validateTestModel{}, ActionParamsType{validRec}, CreateTask)
+			mctest.AssertEquals(t, res.Ok, true, "validate-result should be: ok")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return the per-field messages, by record-index:",
+		TestFunc: func() {
+			invalidRec := ActionParamType{"name": "A", "email": "abc", "age": 12.0, "role": "guest", "code": "abc", "slug": "ABC", "id": "123"}
+			res := ValidateRecords(validateTestModel{}, ActionParamsType{validRec, invalidRec}, CreateTask)
+			mctest.AssertEquals(t, res.Ok, false, "validate-result should be: not ok")
+			mctest.AssertEquals(t, len(res.Errors), 7, "validation errors length should be: 7")
+			mctest.AssertEquals(t, res.Errors["record[1].email"], "must be a valid email-address", "record[1].email message should be: invalid email")
+			mctest.AssertEquals(t, res.Errors["record[1].age"], "must be at least 18", "record[1].age message should be: at least 18")
+			mctest.AssertEquals(t, res.Errors["record[1].slug"], "must be a lowercase slug", "record[1].slug message should be: custom validator message")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should require the missing fields on create, but not on update:",
+		TestFunc: func() {
+			rec := ActionParamType{"age": 20}
+			createRes := ValidateRecords(validateTestModel{}, ActionParamsType{rec}, CreateTask)
+			mctest.AssertEquals(t, createRes.Errors["record[0].name"], "is required", "create record[0].name message should be: is required")
+			updateRes := ValidateRecords(validateTestModel{}, ActionParamsType{rec}, UpdateTask)
+			mctest.AssertEquals(t, updateRes.Ok, true, "update validate-result should be: ok")
+			updateRes = ValidateRecords(validateTestModel{}, ActionParamsType{{"name": ""}}, UpdateTask)
+			mctest.AssertEquals(t, updateRes.Errors["record[0].name"], "is required", "update record[0].name message should be: is required")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should return paramsError from Create, before building the create-query:",
+		TestFunc: func() {
+			crud := NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: validateTestModel{}, TableName: "validate_items"}, CrudOptionsType{})
+			res := crud.Create(ActionParamsType{{"name": "Abi", "email": "abc"}})
+			mctest.AssertEquals(t, res.Code, "paramsError", "create response-code should be: paramsError")
+			errs, _ := res.Value.(MessageObject)
+			mctest.AssertEquals(t, errs["record[0].email"], "must be a valid email-address", "record[0].email message should be: invalid email")
+		},
+	})
+	mctest.McTest(mctest.OptionValue{
+		Name: "should validate the records mutated by the before-create hooks:",
+		TestFunc: func() {
+			hooks := CrudHooksType{BeforeCreate: []HookFunc{func(params *HookParamsType) error {
+				for _, rec := range params.ActionParams {
+					rec["email"] = "abc@xyz.com"
+				}
+				return nil
+			}}}
+			crud := NewCrud(CrudParamsType{AppDb: sqliteDb, ModelRef: validateTestModel{}, TableName: "validate_items"}, CrudOptionsType{Hooks: hooks})
+			rec := ActionParamType{"name": "Abi", "email": "abc", "age": 30, "role": "admin", "code": "ABC;123", "slug": "abc-xyz", "optional": ""}
+			res := crud.Create(ActionParamsType{rec})
+			mctest.AssertEquals(t, res.Code, "success", "create response-code should be: success")
+		},
+	})
+
+	mctest.PostTestResult()
+}